@@ -0,0 +1,133 @@
+package apigee
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DeleteBehaviorSkipIfDeployed    = "skip_if_deployed"
+	DeleteBehaviorUndeployAndDelete = "undeploy_and_delete"
+	DeleteBehaviorFailIfDeployed    = "fail_if_deployed"
+)
+
+type changeChecker interface {
+	HasChange(key string) bool
+}
+
+func bundleChanged(d changeChecker) bool {
+	//Settings such as skip_bundle_validation can change without needing a new revision
+	return d.HasChange("bundle") || d.HasChange("bundle_dir") || d.HasChange("bundle_hash")
+}
+
+func customizeBundleDirDiff(diff *schema.ResourceDiff, root string) error {
+	//Only bundle_dir computes its own hash, bundle requires the caller to supply bundle_hash
+	bundleDir := diff.Get("bundle_dir").(string)
+	if !diff.NewValueKnown("bundle_dir") {
+		return diff.SetNewComputed("bundle_hash")
+	}
+	if bundleDir == "" {
+		return nil
+	}
+	bundleHash, err := client.HashBundleDir(bundleDir, root)
+	if err != nil {
+		return err
+	}
+	if bundleHash != diff.Get("bundle_hash").(string) {
+		return diff.SetNew("bundle_hash", bundleHash)
+	}
+	return nil
+}
+
+func validateBundleDiff(diff *schema.ResourceDiff, root string) error {
+	//Catch broken bundles at plan time instead of with an opaque error from the import
+	if diff.Get("skip_bundle_validation").(bool) {
+		return nil
+	}
+	if !bundleChanged(diff) {
+		return nil
+	}
+	if !diff.NewValueKnown("bundle") || !diff.NewValueKnown("bundle_dir") {
+		return nil
+	}
+	var files map[string][]byte
+	var err error
+	bundleDir := diff.Get("bundle_dir").(string)
+	if bundleDir != "" {
+		files, err = client.ReadBundleDir(bundleDir, root)
+	} else {
+		bundle := diff.Get("bundle").(string)
+		//The zip may not exist yet if it is created by another resource during apply
+		_, err = os.Stat(bundle)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		files, err = client.ReadBundleZip(bundle, root)
+	}
+	if err != nil {
+		return err
+	}
+	return client.ValidateBundle(files, root)
+}
+
+func getBundleFormData(d *schema.ResourceData, root string) (client.FormData, error) {
+	bundleDir := d.Get("bundle_dir").(string)
+	if bundleDir == "" {
+		return client.FormData{Filename: d.Get("bundle").(string)}, nil
+	}
+	data, err := client.ZipBundleDir(bundleDir, root)
+	if err != nil {
+		return client.FormData{}, err
+	}
+	return client.FormData{Filename: root + ".zip", Data: data}, nil
+}
+
+func bundleDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"revision": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"output_path": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"output_path", "output_dir"},
+		},
+		"output_dir": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"output_path", "output_dir"},
+		},
+		"bundle_hash": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func writeBundleDataSource(d *schema.ResourceData, data []byte, root string) error {
+	files, err := client.ReadBundleZipData(data, root)
+	if err != nil {
+		return err
+	}
+	//Same hash as bundle_dir so a download can be compared with the source it should match
+	d.Set("bundle_hash", client.HashBundleFiles(files))
+	outputDir := d.Get("output_dir").(string)
+	if outputDir != "" {
+		return client.ExtractBundle(files, outputDir, root)
+	}
+	outputPath := d.Get("output_path").(string)
+	err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(outputPath, data, 0644)
+}
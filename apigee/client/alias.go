@@ -1,6 +1,10 @@
 package client
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -26,3 +30,40 @@ func AliasDecodeId(s string) (string, string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1], tokens[2]
 }
+
+func (c *Client) GetAlias(envName string, keystoreName string, name string) (*Alias, error) {
+	//The response describes the certificate, none of which is tracked
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(AliasPathGet, c.Organization, envName, keystoreName, name), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Alias{
+		EnvironmentName: envName,
+		KeystoreName:    keystoreName,
+		Name:            name,
+	}, nil
+}
+
+func (c *Client) CreateAlias(in *Alias, formData map[string]FormData) error {
+	requestQuery := url.Values{
+		"alias":                   []string{in.Name},
+		"format":                  []string{in.Format},
+		"ignoreExpiryValidation":  []string{strconv.FormatBool(in.IgnoreExpiryValidation)},
+		"ignoreNewlineValidation": []string{strconv.FormatBool(in.IgnoreNewlineValidation)},
+	}
+	return c.multipartRequest(http.MethodPost, fmt.Sprintf(AliasPath, c.Organization, in.EnvironmentName, in.KeystoreName), requestQuery, formData, nil)
+}
+
+func (c *Client) UpdateAliasCertificate(in *Alias, file string) error {
+	requestQuery := url.Values{
+		"ignoreExpiryValidation": []string{strconv.FormatBool(in.IgnoreExpiryValidation)},
+	}
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPut, fmt.Sprintf(AliasPathGet, c.Organization, in.EnvironmentName, in.KeystoreName, in.Name), requestQuery, formData, nil)
+}
+
+func (c *Client) DeleteAlias(envName string, keystoreName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(AliasPathGet, c.Organization, envName, keystoreName, name), nil, nil, nil)
+}
@@ -1,6 +1,13 @@
 package client
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 const (
 	DeveloperAppPath             = "organizations/%s/developers/%s/apps"
@@ -22,6 +29,10 @@ type App struct {
 	CompanyName string `json:"-"`
 }
 
+type GoogleAppList struct {
+	Apps []App `json:"app"`
+}
+
 func (ur *App) DeveloperAppEncodeId() string {
 	return ur.DeveloperEmail + IdSeparator + ur.Name
 }
@@ -34,3 +45,96 @@ func AppDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetDeveloperApp(developerEmail string, name string) (*App, error) {
+	retVal := &App{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(DeveloperAppPathGet, c.Organization, developerEmail, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.DeveloperEmail = developerEmail
+	return retVal, nil
+}
+
+func (c *Client) CreateDeveloperApp(in *App) (*App, error) {
+	//The response includes the generated credentials
+	retVal := &App{}
+	err := c.jsonRequest(http.MethodPost, fmt.Sprintf(DeveloperAppPath, c.Organization, in.DeveloperEmail), nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.DeveloperEmail = in.DeveloperEmail
+	return retVal, nil
+}
+
+func (c *Client) UpdateDeveloperApp(in *App) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(DeveloperAppPathGet, c.Organization, in.DeveloperEmail, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteDeveloperApp(developerEmail string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(DeveloperAppPathGet, c.Organization, developerEmail, name), nil, nil, nil)
+}
+
+func (c *Client) DeleteDeveloperAppGeneratedKey(developerEmail string, name string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(DeveloperAppPathGeneratedKey, c.Organization, developerEmail, name, key), nil, nil, nil)
+}
+
+func (c *Client) GetCompanyApp(companyName string, name string) (*App, error) {
+	retVal := &App{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(CompanyAppPathGet, c.Organization, companyName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.CompanyName = companyName
+	return retVal, nil
+}
+
+func (c *Client) CreateCompanyApp(in *App) (*App, error) {
+	//The response includes the generated credentials
+	retVal := &App{}
+	err := c.jsonRequest(http.MethodPost, fmt.Sprintf(CompanyAppPath, c.Organization, in.CompanyName), nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.CompanyName = in.CompanyName
+	return retVal, nil
+}
+
+func (c *Client) UpdateCompanyApp(in *App) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(CompanyAppPathGet, c.Organization, in.CompanyName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteCompanyApp(companyName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyAppPathGet, c.Organization, companyName, name), nil, nil, nil)
+}
+
+func (c *Client) DeleteCompanyAppGeneratedKey(companyName string, name string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyAppPathGeneratedKey, c.Organization, companyName, name, key), nil, nil, nil)
+}
+
+func (c *Client) ListDeveloperApps(developerEmail string) ([]string, error) {
+	requestPath := fmt.Sprintf(DeveloperAppPath, c.Organization, developerEmail)
+	if !c.IsGoogle() {
+		return c.ListByStartKey(requestPath, nil, DecodeNamePage)
+	}
+	//Without expand, Google only returns app ids instead of names
+	requestQuery := url.Values{
+		"expand": []string{"true"},
+	}
+	return c.ListByStartKey(requestPath, requestQuery, func(body io.Reader) ([]string, error) {
+		page := &GoogleAppList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return nil, err
+		}
+		retVal := []string{}
+		for _, app := range page.Apps {
+			retVal = append(retVal, app.Name)
+		}
+		return retVal, nil
+	})
+}
+
+func (c *Client) ListCompanyApps(companyName string) ([]string, error) {
+	return c.ListByStartKey(fmt.Sprintf(CompanyAppPath, c.Organization, companyName), nil, DecodeNamePage)
+}
@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	DeveloperAppCredentialPath        = "organizations/%s/developers/%s/apps/%s/keys"
@@ -56,3 +60,71 @@ func AppCredentialDecodeId(s string) (string, string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1], tokens[2]
 }
+
+func (c *Client) GetDeveloperAppCredential(developerEmail string, appName string, key string) (*AppCredential, error) {
+	retVal := &AppCredential{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(DeveloperAppCredentialPathGet, c.Organization, developerEmail, appName, key), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.DeveloperEmail = developerEmail
+	retVal.AppName = appName
+	return retVal, nil
+}
+
+func (c *Client) CreateDeveloperAppCredential(in *AppCredentialModify) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(DeveloperAppCredentialPathCreate, c.Organization, in.DeveloperEmail, in.AppName), nil, in, nil)
+}
+
+func (c *Client) UpdateDeveloperAppCredential(in *AppCredentialModify) error {
+	requestPath := fmt.Sprintf(DeveloperAppCredentialPathGet, c.Organization, in.DeveloperEmail, in.AppName, in.ConsumerKey)
+	//Products and attributes are only changed with POST
+	err := c.idempotentJsonRequest(http.MethodPost, requestPath, nil, in, nil)
+	if err != nil {
+		return err
+	}
+	//Scopes are only changed with PUT
+	return c.jsonRequest(http.MethodPut, requestPath, nil, in, nil)
+}
+
+func (c *Client) RemoveDeveloperAppCredentialProduct(developerEmail string, appName string, key string, product string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(DeveloperAppCredentialPathProduct, c.Organization, developerEmail, appName, key, product), nil, nil, nil)
+}
+
+func (c *Client) DeleteDeveloperAppCredential(developerEmail string, appName string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(DeveloperAppCredentialPathGet, c.Organization, developerEmail, appName, key), nil, nil, nil)
+}
+
+func (c *Client) GetCompanyAppCredential(companyName string, appName string, key string) (*AppCredential, error) {
+	retVal := &AppCredential{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(CompanyAppCredentialPathGet, c.Organization, companyName, appName, key), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.CompanyName = companyName
+	retVal.AppName = appName
+	return retVal, nil
+}
+
+func (c *Client) CreateCompanyAppCredential(in *AppCredentialModify) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(CompanyAppCredentialPathCreate, c.Organization, in.CompanyName, in.AppName), nil, in, nil)
+}
+
+func (c *Client) UpdateCompanyAppCredential(in *AppCredentialModify) error {
+	requestPath := fmt.Sprintf(CompanyAppCredentialPathGet, c.Organization, in.CompanyName, in.AppName, in.ConsumerKey)
+	//Products and attributes are only changed with POST
+	err := c.idempotentJsonRequest(http.MethodPost, requestPath, nil, in, nil)
+	if err != nil {
+		return err
+	}
+	//Scopes are only changed with PUT
+	return c.jsonRequest(http.MethodPut, requestPath, nil, in, nil)
+}
+
+func (c *Client) RemoveCompanyAppCredentialProduct(companyName string, appName string, key string, product string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyAppCredentialPathProduct, c.Organization, companyName, appName, key, product), nil, nil, nil)
+}
+
+func (c *Client) DeleteCompanyAppCredential(companyName string, appName string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyAppCredentialPathGet, c.Organization, companyName, appName, key), nil, nil, nil)
+}
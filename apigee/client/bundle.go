@@ -0,0 +1,186 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ProxyBundleRoot      = "apiproxy"
+	SharedFlowBundleRoot = "sharedflowbundle"
+	//Left in every extracted root directory so it is only ever replaced if the provider wrote it
+	BundleMarkerFile = ".terraform-provider-apigee"
+)
+
+var (
+	//Every entry gets the same timestamp so zipping an unchanged directory always produces the same bytes
+	bundleModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type BundleFile struct {
+	//Slash separated path inside the bundle, including the root directory
+	Name string
+	Path string
+}
+
+func ListBundleDir(dir string, root string) ([]BundleFile, error) {
+	//Allow pointing at either the root directory itself or the directory that contains it
+	rootDir := dir
+	if filepath.Base(filepath.Clean(dir)) != root {
+		rootDir = filepath.Join(dir, root)
+	}
+	info, err := os.Stat(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("bundle directory %s must contain a %s directory: %v", dir, root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("bundle directory %s must contain a %s directory", dir, root)
+	}
+	retVal := []BundleFile{}
+	//Walk visits entries in lexical order which keeps the zip deterministic
+	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		//Skip editor and OS clutter such as .DS_Store
+		if strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+		retVal = append(retVal, BundleFile{
+			Name: root + "/" + filepath.ToSlash(rel),
+			Path: path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(retVal) == 0 {
+		return nil, fmt.Errorf("bundle directory %s is empty", rootDir)
+	}
+	return retVal, nil
+}
+
+func ZipBundleDir(dir string, root string) ([]byte, error) {
+	files, err := ListBundleDir(dir, root)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		header := &zip.FileHeader{
+			Name:   f.Name,
+			Method: zip.Deflate,
+		}
+		header.Modified = bundleModTime
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.Write(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func HashBundleDir(dir string, root string) (string, error) {
+	files, err := ReadBundleDir(dir, root)
+	if err != nil {
+		return "", err
+	}
+	return HashBundleFiles(files), nil
+}
+
+func HashBundleFiles(files map[string][]byte) string {
+	//Hash the names and contents rather than the zip so the hash does not change if compression does
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		contentHash := sha256.Sum256(files[name])
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(contentHash[:])
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func ExtractBundle(files map[string][]byte, dir string, root string) error {
+	rootDir := filepath.Join(dir, root)
+	targets := map[string]string{}
+	for name := range files {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		//Refuse entries such as ../../etc/passwd that would escape the root directory, before writing anything
+		rel, err := filepath.Rel(rootDir, target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("bundle entry %s is outside of %s", name, root)
+		}
+		targets[name] = target
+	}
+	//Nothing to do when the directory already holds exactly this bundle, which keeps plans from rewriting it
+	existing, err := ReadBundleDir(rootDir, root)
+	if (err == nil) && (HashBundleFiles(existing) == HashBundleFiles(files)) {
+		return nil
+	}
+	//Never remove a directory with files the provider did not write, such as a source checkout
+	entries, err := ioutil.ReadDir(rootDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(entries) > 0 {
+		_, err = os.Stat(filepath.Join(rootDir, BundleMarkerFile))
+		if err != nil {
+			return fmt.Errorf("%s was not written by this provider and would be replaced, point output_dir at an empty directory instead", rootDir)
+		}
+	}
+	//Replace any previous extraction so files removed from the bundle do not linger
+	err = os.RemoveAll(rootDir)
+	if err != nil {
+		return err
+	}
+	for name, target := range targets {
+		err = os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(target, files[name], 0644)
+		if err != nil {
+			return err
+		}
+	}
+	err = os.MkdirAll(rootDir, 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(rootDir, BundleMarkerFile), []byte("Written by terraform-provider-apigee, replaced on every read\n"), 0644)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractBundleReplacesPreviousExtraction(t *testing.T) {
+	dir := t.TempDir()
+	err := ExtractBundle(map[string][]byte{
+		"apiproxy/test.xml":         []byte("<APIProxy/>"),
+		"apiproxy/policies/Old.xml": []byte("<AssignMessage/>"),
+	}, dir, ProxyBundleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(dir, ProxyBundleRoot, BundleMarkerFile))
+	if err != nil {
+		t.Fatalf("expected the marker file to be written: %v", err)
+	}
+	err = ExtractBundle(map[string][]byte{
+		"apiproxy/test.xml":         []byte("<APIProxy/>"),
+		"apiproxy/policies/New.xml": []byte("<AssignMessage/>"),
+	}, dir, ProxyBundleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(dir, ProxyBundleRoot, "policies", "Old.xml"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected a file removed from the bundle to be removed, got %v", err)
+	}
+	_, err = os.Stat(filepath.Join(dir, ProxyBundleRoot, "policies", "New.xml"))
+	if err != nil {
+		t.Errorf("expected a file added to the bundle to be written: %v", err)
+	}
+}
+
+func TestExtractBundleSkipsUnchangedBundle(t *testing.T) {
+	//Files that are not part of the bundle hash show whether the directory was rewritten
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"apiproxy/test.xml": []byte("<APIProxy/>"),
+	}
+	rootDir := filepath.Join(dir, ProxyBundleRoot)
+	err := os.MkdirAll(rootDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"test.xml": "<APIProxy/>", ".gitkeep": ""} {
+		err = ioutil.WriteFile(filepath.Join(rootDir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = ExtractBundle(files, dir, ProxyBundleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(rootDir, ".gitkeep"))
+	if err != nil {
+		t.Errorf("expected an unchanged bundle to be left alone: %v", err)
+	}
+}
+
+func TestExtractBundleRefusesUnmarkedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	rootDir := filepath.Join(dir, SharedFlowBundleRoot)
+	err := os.MkdirAll(rootDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(rootDir, "work.xml"), []byte("<SharedFlowBundle/>"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ExtractBundle(map[string][]byte{
+		"sharedflowbundle/test.xml": []byte("<SharedFlowBundle/>"),
+	}, dir, SharedFlowBundleRoot)
+	if (err == nil) || !strings.Contains(err.Error(), "not written by this provider") {
+		t.Fatalf("expected the directory to be refused, got %v", err)
+	}
+	_, err = os.Stat(filepath.Join(rootDir, "work.xml"))
+	if err != nil {
+		t.Errorf("expected existing files to be kept: %v", err)
+	}
+}
+
+func TestExtractBundleRefusesEscapingEntries(t *testing.T) {
+	dir := t.TempDir()
+	err := ExtractBundle(map[string][]byte{
+		"apiproxy/../../escape.xml": []byte("<APIProxy/>"),
+	}, dir, ProxyBundleRoot)
+	if err == nil {
+		t.Fatal("expected an entry outside of the root directory to be refused")
+	}
+}
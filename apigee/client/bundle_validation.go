@@ -0,0 +1,405 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	PoliciesDir    = "policies"
+	ResourcesDir   = "resources"
+	ProxiesDir     = "proxies"
+	TargetsDir     = "targets"
+	SharedFlowsDir = "sharedflows"
+)
+
+var (
+	//Schemes of policy resource URLs that must be found in the resources directory of the bundle
+	resourceURLSchemes = []string{"jsc", "java", "py"}
+)
+
+type BundleProblem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (p BundleProblem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+type BundleValidationError struct {
+	Problems []BundleProblem
+}
+
+func (e *BundleValidationError) Error() string {
+	lines := []string{"invalid bundle:"}
+	for _, p := range e.Problems {
+		lines = append(lines, "  "+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type xmlNode struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Line     int
+	Children []*xmlNode
+}
+
+func (n *xmlNode) walk(visit func(node *xmlNode, parent *xmlNode)) {
+	for _, child := range n.Children {
+		visit(child, n)
+		child.walk(visit)
+	}
+}
+
+func (n *xmlNode) children(name string) []*xmlNode {
+	retVal := []*xmlNode{}
+	for _, child := range n.Children {
+		if child.Name == name {
+			retVal = append(retVal, child)
+		}
+	}
+	return retVal
+}
+
+func ReadBundleZip(filename string, root string) (map[string][]byte, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	retVal, err := ReadBundleZipData(data, root)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s: %v", filename, err)
+	}
+	return retVal, nil
+}
+
+func ReadBundleZipData(data []byte, root string) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	retVal := map[string][]byte{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.HasPrefix(f.Name, root+"/") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		retVal[f.Name] = content
+	}
+	if len(retVal) == 0 {
+		return nil, fmt.Errorf("must contain a %s directory", root)
+	}
+	return retVal, nil
+}
+
+func ReadBundleDir(dir string, root string) (map[string][]byte, error) {
+	files, err := ListBundleDir(dir, root)
+	if err != nil {
+		return nil, err
+	}
+	retVal := map[string][]byte{}
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		retVal[f.Name] = content
+	}
+	return retVal, nil
+}
+
+func ValidateBundle(files map[string][]byte, root string) error {
+	problems := []BundleProblem{}
+	//Parse every XML file, grouped by the directory it lives in
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	descriptors := map[string]*xmlNode{}
+	byDir := map[string]map[string]*xmlNode{
+		PoliciesDir:    {},
+		ProxiesDir:     {},
+		TargetsDir:     {},
+		SharedFlowsDir: {},
+	}
+	for _, name := range names {
+		rel := strings.TrimPrefix(name, root+"/")
+		dir, file := path.Split(rel)
+		dir = strings.TrimSuffix(dir, "/")
+		if path.Ext(file) != ".xml" {
+			continue
+		}
+		group, ok := byDir[dir]
+		if (dir != "") && !ok {
+			continue
+		}
+		doc, problem := parseBundleXml(name, files[name])
+		if problem != nil {
+			problems = append(problems, *problem)
+			continue
+		}
+		if dir == "" {
+			descriptors[name] = doc
+		} else {
+			group[name] = doc
+		}
+	}
+	//Policies are referenced by the name attribute, which conventionally matches the file name
+	policyNames := map[string]bool{}
+	for name, doc := range byDir[PoliciesDir] {
+		policyNames[nodeName(name, doc)] = true
+		problems = append(problems, checkPolicyResources(files, root, name, doc)...)
+	}
+	flowDirs := []string{ProxiesDir, TargetsDir, SharedFlowsDir}
+	for _, dir := range flowDirs {
+		for name, doc := range byDir[dir] {
+			doc.walk(func(node *xmlNode, parent *xmlNode) {
+				if (node.Name != "Name") || (parent.Name != "Step") {
+					return
+				}
+				policyName := strings.TrimSpace(node.Text)
+				if policyName == "" {
+					problems = append(problems, BundleProblem{
+						File:    name,
+						Line:    node.Line,
+						Message: "step has an empty policy name",
+					})
+					return
+				}
+				if !policyNames[policyName] {
+					problems = append(problems, BundleProblem{
+						File:    name,
+						Line:    node.Line,
+						Message: fmt.Sprintf("step references policy %s which does not exist in %s/%s", policyName, root, PoliciesDir),
+					})
+				}
+			})
+		}
+	}
+	if root == ProxyBundleRoot {
+		problems = append(problems, checkProxyEndpoints(root, descriptors, byDir[ProxiesDir], byDir[TargetsDir])...)
+	} else {
+		problems = append(problems, checkEndpointNames(byDir[SharedFlowsDir], "SharedFlow")...)
+		problems = append(problems, checkDescriptorReferences(descriptors, "SharedFlows", "SharedFlow", byDir[SharedFlowsDir])...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	return &BundleValidationError{Problems: problems}
+}
+
+func checkPolicyResources(files map[string][]byte, root string, name string, doc *xmlNode) []BundleProblem {
+	retVal := []BundleProblem{}
+	doc.walk(func(node *xmlNode, parent *xmlNode) {
+		if (node.Name != "ResourceURL") && (node.Name != "IncludeURL") {
+			return
+		}
+		resourceURL := strings.TrimSpace(node.Text)
+		for _, scheme := range resourceURLSchemes {
+			prefix := scheme + "://"
+			if !strings.HasPrefix(resourceURL, prefix) {
+				continue
+			}
+			resourceFile := path.Join(root, ResourcesDir, scheme, strings.TrimPrefix(resourceURL, prefix))
+			if _, ok := files[resourceFile]; !ok {
+				retVal = append(retVal, BundleProblem{
+					File:    name,
+					Line:    node.Line,
+					Message: fmt.Sprintf("%s references %s which does not exist", node.Name, resourceFile),
+				})
+			}
+		}
+	})
+	return retVal
+}
+
+func checkProxyEndpoints(root string, descriptors map[string]*xmlNode, proxies map[string]*xmlNode, targets map[string]*xmlNode) []BundleProblem {
+	retVal := []BundleProblem{}
+	if len(proxies) == 0 {
+		retVal = append(retVal, BundleProblem{
+			File:    root + "/" + ProxiesDir,
+			Message: "bundle must contain at least one proxy endpoint",
+		})
+	}
+	retVal = append(retVal, checkEndpointNames(proxies, "ProxyEndpoint")...)
+	retVal = append(retVal, checkEndpointNames(targets, "TargetEndpoint")...)
+	targetNames := map[string]bool{}
+	for name, doc := range targets {
+		targetNames[nodeName(name, doc)] = true
+	}
+	for name, doc := range proxies {
+		doc.walk(func(node *xmlNode, parent *xmlNode) {
+			if (node.Name != "TargetEndpoint") || (parent.Name != "RouteRule") {
+				return
+			}
+			targetName := strings.TrimSpace(node.Text)
+			if !targetNames[targetName] {
+				retVal = append(retVal, BundleProblem{
+					File:    name,
+					Line:    node.Line,
+					Message: fmt.Sprintf("route rule references target endpoint %s which does not exist in %s/%s", targetName, root, TargetsDir),
+				})
+			}
+		})
+	}
+	retVal = append(retVal, checkDescriptorReferences(descriptors, "ProxyEndpoints", "ProxyEndpoint", proxies)...)
+	retVal = append(retVal, checkDescriptorReferences(descriptors, "TargetEndpoints", "TargetEndpoint", targets)...)
+	return retVal
+}
+
+func checkEndpointNames(endpoints map[string]*xmlNode, element string) []BundleProblem {
+	retVal := []BundleProblem{}
+	for name, doc := range endpoints {
+		fileName := strings.TrimSuffix(path.Base(name), ".xml")
+		if doc.Name != element {
+			retVal = append(retVal, BundleProblem{
+				File:    name,
+				Line:    doc.Line,
+				Message: fmt.Sprintf("root element must be %s, not %s", element, doc.Name),
+			})
+			continue
+		}
+		if endpointName, ok := doc.Attrs["name"]; ok && (endpointName != fileName) {
+			retVal = append(retVal, BundleProblem{
+				File:    name,
+				Line:    doc.Line,
+				Message: fmt.Sprintf("%s name %s does not match the file name %s", element, endpointName, fileName),
+			})
+		}
+	}
+	return retVal
+}
+
+func checkDescriptorReferences(descriptors map[string]*xmlNode, listElement string, element string, endpoints map[string]*xmlNode) []BundleProblem {
+	//The root descriptor optionally lists its endpoints, each of which must exist
+	retVal := []BundleProblem{}
+	endpointNames := map[string]bool{}
+	for name, doc := range endpoints {
+		endpointNames[nodeName(name, doc)] = true
+	}
+	for name, doc := range descriptors {
+		for _, list := range doc.children(listElement) {
+			for _, ref := range list.children(element) {
+				endpointName := strings.TrimSpace(ref.Text)
+				if !endpointNames[endpointName] {
+					retVal = append(retVal, BundleProblem{
+						File:    name,
+						Line:    ref.Line,
+						Message: fmt.Sprintf("%s %s does not exist", element, endpointName),
+					})
+				}
+			}
+		}
+	}
+	return retVal
+}
+
+func nodeName(filename string, doc *xmlNode) string {
+	if name, ok := doc.Attrs["name"]; ok {
+		return name
+	}
+	return strings.TrimSuffix(path.Base(filename), ".xml")
+}
+
+func parseBundleXml(filename string, content []byte) (*xmlNode, *BundleProblem) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	//Bundles commonly declare encodings other than UTF-8, which only matter for text we do not inspect
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	var doc *xmlNode
+	stack := []*xmlNode{}
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problem := &BundleProblem{
+				File:    filename,
+				Message: err.Error(),
+			}
+			if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+				problem.Line = syntaxErr.Line
+				problem.Message = syntaxErr.Msg
+			}
+			return nil, problem
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{
+				Name:  t.Name.Local,
+				Attrs: map[string]string{},
+				Line:  lineAt(content, offset),
+			}
+			for _, attr := range t.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) == 0 {
+				if doc != nil {
+					return nil, &BundleProblem{
+						File:    filename,
+						Line:    node.Line,
+						Message: "multiple root elements",
+					}
+				}
+				doc = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+	if doc == nil {
+		return nil, &BundleProblem{
+			File:    filename,
+			Message: "no root element",
+		}
+	}
+	return doc, nil
+}
+
+func lineAt(content []byte, offset int64) int {
+	//The offset is where the token starts, so a start tag spread over several lines is reported on its first line
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
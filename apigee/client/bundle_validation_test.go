@@ -0,0 +1,81 @@
+package client
+
+import (
+	"testing"
+)
+
+func validationProblems(t *testing.T, files map[string][]byte, root string) []BundleProblem {
+	err := ValidateBundle(files, root)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*BundleValidationError)
+	if !ok {
+		t.Fatalf("expected a BundleValidationError, got %v", err)
+	}
+	return validationErr.Problems
+}
+
+func TestValidateBundleReportsStepProblems(t *testing.T) {
+	files := map[string][]byte{
+		"apiproxy/test.xml": []byte(`<APIProxy name="test">
+  <ProxyEndpoints>
+    <ProxyEndpoint>default</ProxyEndpoint>
+  </ProxyEndpoints>
+</APIProxy>`),
+		"apiproxy/policies/Existing.xml": []byte(`<AssignMessage name="Existing"/>`),
+		"apiproxy/proxies/default.xml": []byte(`<ProxyEndpoint name="default">
+  <PreFlow>
+    <Request>
+      <Step>
+        <Name>Existing</Name>
+      </Step>
+      <Step>
+        <Name>  </Name>
+      </Step>
+      <Step>
+        <Name
+          >Missing</Name>
+      </Step>
+    </Request>
+  </PreFlow>
+  <HTTPProxyConnection>
+    <BasePath>/test</BasePath>
+  </HTTPProxyConnection>
+</ProxyEndpoint>`),
+	}
+	expected := map[string]int{
+		"step has an empty policy name":                                            8,
+		"step references policy Missing which does not exist in apiproxy/policies": 11,
+	}
+	for _, problem := range validationProblems(t, files, ProxyBundleRoot) {
+		line, found := expected[problem.Message]
+		if !found {
+			continue
+		}
+		if (problem.File != "apiproxy/proxies/default.xml") || (problem.Line != line) {
+			t.Errorf("expected %q at apiproxy/proxies/default.xml:%d, got %s", problem.Message, line, problem)
+		}
+		delete(expected, problem.Message)
+	}
+	for message := range expected {
+		t.Errorf("expected problem %q", message)
+	}
+}
+
+func TestLineAtReportsStartOfToken(t *testing.T) {
+	content := []byte("<A>\n  <B\n    attr=\"x\"\n  />\n</A>")
+	tests := []struct {
+		offset   int64
+		expected int
+	}{
+		{0, 1},
+		{6, 2},
+		{int64(len(content)) + 10, 5},
+	}
+	for _, test := range tests {
+		if got := lineAt(content, test.offset); got != test.expected {
+			t.Errorf("offset %d: expected line %d, got %d", test.offset, test.expected, got)
+		}
+	}
+}
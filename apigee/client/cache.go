@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	CachePath    = "organizations/%s/environments/%s/caches"
@@ -34,3 +38,25 @@ func CacheDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetCache(envName string, name string) (*Cache, error) {
+	retVal := &Cache{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(CachePathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateCache(in *Cache) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(CachePath, c.Organization, in.EnvironmentName), nil, in, nil)
+}
+
+func (c *Client) UpdateCache(in *Cache) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(CachePathGet, c.Organization, in.EnvironmentName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteCache(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CachePathGet, c.Organization, envName, name), nil, nil, nil)
+}
@@ -9,10 +9,11 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -31,109 +32,245 @@ const (
 )
 
 type Client struct {
-	username        string
-	password        string
-	accessToken     string
-	useSSL          bool
-	server          string
-	serverPath      string
-	port            int
-	oauthServer     string
-	oauthServerPath string
-	oauthPort       int
-	Organization    string
-	httpClient      *http.Client
+	username          string
+	password          string
+	accessToken       string
+	useSSL            bool
+	server            string
+	serverPath        string
+	port              int
+	oauthServer       string
+	oauthServerPath   string
+	oauthPort         int
+	oauthClientId     string
+	oauthClientSecret string
+	mfaToken          string
+	passcode          string
+	Organization      string
+	maxRetries        int
+	baseBackoff       int
+	maxBackoff        int
+	refreshToken      string
+	tokenExpiry       time.Time
+	serviceAccountKey *ServiceAccountKey
+	tokenMutex        sync.Mutex
+	httpClient        *http.Client
+	basePaths         map[string][]string
+	basePathsMutex    sync.Mutex
 }
 type FormData struct {
 	Filename string
 	Text     string
+	Data     []byte
 }
 
-func NewClient(username string, password string, accessToken string, useSSL bool, server string, serverPath string, port int, oauthServer string, oauthServerPath string, oauthPort int, organization string) (client *Client, err error) {
+func NewClient(username string, password string, accessToken string, useSSL bool, server string, serverPath string, port int, oauthServer string, oauthServerPath string, oauthPort int, organization string, maxRetries int, baseBackoff int, maxBackoff int, serviceAccountKey string, oauthClientId string, oauthClientSecret string, mfaToken string, passcode string) (client *Client, err error) {
 	c := &Client{
-		username:        username,
-		password:        password,
-		accessToken:     accessToken,
-		useSSL:          useSSL,
-		server:          server,
-		serverPath:      serverPath,
-		port:            port,
-		oauthServer:     oauthServer,
-		oauthServerPath: oauthServerPath,
-		oauthPort:       oauthPort,
-		Organization:    organization,
-		httpClient:      &http.Client{},
-	}
-	//Check for oauth authentication and try to get access token
-	if c.oauthServer != "" {
-		log.Print("Apigee Management API: Obtaining access token...")
-		var requestURL string
-		var scheme string
-		if c.useSSL {
-			scheme = HTTPS
-		} else {
-			scheme = HTTP
-		}
-		if c.oauthServerPath != "" {
-			requestURL = fmt.Sprintf("%s://%s:%d/%s/%s", scheme, c.oauthServer, c.oauthPort, c.oauthServerPath, OauthTokenPath)
-		} else {
-			requestURL = fmt.Sprintf("%s://%s:%d/%s", scheme, c.oauthServer, c.oauthPort, OauthTokenPath)
+		username:          username,
+		password:          password,
+		accessToken:       accessToken,
+		useSSL:            useSSL,
+		server:            server,
+		serverPath:        serverPath,
+		port:              port,
+		oauthServer:       oauthServer,
+		oauthServerPath:   oauthServerPath,
+		oauthPort:         oauthPort,
+		oauthClientId:     oauthClientId,
+		oauthClientSecret: oauthClientSecret,
+		mfaToken:          mfaToken,
+		passcode:          passcode,
+		Organization:      organization,
+		maxRetries:        maxRetries,
+		baseBackoff:       baseBackoff,
+		maxBackoff:        maxBackoff,
+		httpClient:        &http.Client{},
+	}
+	//Check for service account authentication
+	if serviceAccountKey != "" {
+		c.serviceAccountKey, err = ParseServiceAccountKey(serviceAccountKey)
+		if err != nil {
+			return nil, err
 		}
-		requestForm := url.Values{
-			"grant_type": []string{"password"},
-			"username":   []string{c.username},
-			"password":   []string{c.password},
+	}
+	//Check for oauth or service account authentication and try to get access token
+	if c.usesOauth() {
+		err = c.authenticate()
+		if err != nil {
+			return nil, err
 		}
-		req, err := http.NewRequest(http.MethodPost, requestURL, bytes.NewBufferString(requestForm.Encode()))
+	}
+	return c, nil
+}
+
+func (c *Client) IsPublic() bool {
+	return (c.server == PublicApigeeServer) || (c.server == GoogleApigeeServer)
+}
+
+func (c *Client) IsGoogle() bool {
+	return c.server == GoogleApigeeServer
+}
+
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	//Send requests through a different transport, such as one that redirects them to a fake server in tests
+	c.httpClient.Transport = transport
+}
+
+func (c *Client) HttpRequest(method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer) (closer io.ReadCloser, err error) {
+	return c.httpRequest(method, path, query, headerMap, body, isIdempotentMethod(method))
+}
+
+func (c *Client) IdempotentHttpRequest(method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer) (closer io.ReadCloser, err error) {
+	//For POST requests that are safe to repeat, such as deploying a specific revision
+	return c.httpRequest(method, path, query, headerMap, body, true)
+}
+
+func (c *Client) httpRequest(method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer, idempotent bool) (closer io.ReadCloser, err error) {
+	//Keep a copy of the body so it can be resent on retry
+	var bodyBytes []byte
+	if body != nil {
+		bodyBytes = body.Bytes()
+	}
+	retries := 0
+	reauthenticated := false
+	for {
+		//Renew an oauth access token that is about to expire before using it
+		err := c.ensureAccessToken()
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set(headers.Authorization, Basic+" "+SSOClientCredentials)
-		req.Header.Set(headers.ContentType, FormEncoded)
-		requestDump, err := httputil.DumpRequest(req, true)
+		req, err := c.newRequest(method, path, query, headerMap, bodyBytes)
 		if err != nil {
-			log.Print("Apigee Management API:")
-			log.Print(err)
-		} else {
-			log.Print("Apigee Management API: " + string(requestDump))
+			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
 		}
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			//A request that never reached the server is always safe to send again
+			if (idempotent || isConnectError(err)) && (retries < c.maxRetries) {
+				wait := c.retryBackoff(retries, nil)
+				retries++
+				log.Printf("Apigee Management API: Request failed with %v, retrying in %s (attempt %d of %d)", err, wait, retries, c.maxRetries)
+				time.Sleep(wait)
+				continue
+			}
 			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
 		}
-		if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
-			respBody := new(bytes.Buffer)
-			_, err := respBody.ReadFrom(resp.Body)
+		if (resp.StatusCode >= http.StatusOK) && (resp.StatusCode < http.StatusMultipleChoices) {
+			return resp.Body, nil
+		}
+		//Token may have been revoked or expired early so obtain a new one and try once more
+		if (resp.StatusCode == http.StatusUnauthorized) && c.usesOauth() && !reauthenticated {
+			resp.Body.Close()
+			reauthenticated = true
+			log.Print("Apigee Management API: Received status 401, obtaining a new access token")
+			err = c.reauthenticate()
 			if err != nil {
-				return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
+				return nil, err
 			}
-			return nil, &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
+			continue
 		}
-		//Parse body to extract access_token
-		token := &OauthToken{}
-		err = json.NewDecoder(resp.Body).Decode(token)
+		if isRetryableResponse(resp.StatusCode, idempotent) && (retries < c.maxRetries) {
+			wait := c.retryBackoff(retries, resp)
+			resp.Body.Close()
+			retries++
+			log.Printf("Apigee Management API: Received status %d, retrying in %s (attempt %d of %d)", resp.StatusCode, wait, retries, c.maxRetries)
+			time.Sleep(wait)
+			continue
+		}
+		respBody := new(bytes.Buffer)
+		_, err = respBody.ReadFrom(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			return nil, err
+			return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
 		}
-		log.Print("Apigee Management API: Received access token: " + token.AccessToken)
-		//Inject token as access_token for client for all future calls
-		c.accessToken = token.AccessToken
+		return nil, &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
 	}
-	return c, nil
 }
 
-func (c *Client) IsPublic() bool {
-	return (c.server == PublicApigeeServer) || (c.server == GoogleApigeeServer)
+func (c *Client) jsonRequest(method string, requestPath string, query url.Values, in interface{}, out interface{}) error {
+	return c.sendJson(method, requestPath, query, in, out, isIdempotentMethod(method))
 }
 
-func (c *Client) IsGoogle() bool {
-	return c.server == GoogleApigeeServer
+func (c *Client) idempotentJsonRequest(method string, requestPath string, query url.Values, in interface{}, out interface{}) error {
+	return c.sendJson(method, requestPath, query, in, out, true)
 }
 
-func (c *Client) HttpRequest(method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer) (closer io.ReadCloser, err error) {
-	req, err := http.NewRequest(method, c.requestPath(path), body)
+func (c *Client) sendJson(method string, requestPath string, query url.Values, in interface{}, out interface{}, idempotent bool) error {
+	buf := bytes.Buffer{}
+	var requestHeaders http.Header
+	if in != nil {
+		err := json.NewEncoder(&buf).Encode(in)
+		if err != nil {
+			return err
+		}
+		requestHeaders = http.Header{
+			headers.ContentType: []string{ApplicationJson},
+		}
+	}
+	body, err := c.httpRequest(method, requestPath, query, requestHeaders, &buf, idempotent)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(body).Decode(out)
+}
+
+func (c *Client) formRequest(method string, requestPath string, form url.Values, idempotent bool) error {
+	requestHeaders := http.Header{
+		headers.ContentType: []string{FormEncoded},
+	}
+	body, err := c.httpRequest(method, requestPath, nil, requestHeaders, bytes.NewBufferString(form.Encode()), idempotent)
+	if err != nil {
+		return err
+	}
+	body.Close()
+	return nil
+}
+
+func (c *Client) multipartRequest(method string, requestPath string, query url.Values, formData map[string]FormData, out interface{}) error {
+	//Turn files into multi part buffer
+	mp, buf, err := GetMultiPartBuffer(formData)
+	if err != nil {
+		return err
+	}
+	requestHeaders := http.Header{
+		headers.ContentType: []string{mp.FormDataContentType()},
+	}
+	body, err := c.HttpRequest(method, requestPath, query, requestHeaders, buf)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(body).Decode(out)
+}
+
+func (c *Client) xmlFileRequest(method string, requestPath string, file string) error {
+	//Turn filename into buffer
+	buf, err := GetBuffer(file)
+	if err != nil {
+		return err
+	}
+	requestHeaders := http.Header{
+		headers.ContentType: []string{ApplicationXml},
+	}
+	body, err := c.HttpRequest(method, requestPath, nil, requestHeaders, buf)
 	if err != nil {
-		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+		return err
+	}
+	body.Close()
+	return nil
+}
+
+func (c *Client) newRequest(method string, path string, query url.Values, headerMap http.Header, bodyBytes []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.requestPath(path), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
 	}
 	//Handle query values
 	if query != nil {
@@ -154,31 +291,14 @@ func (c *Client) HttpRequest(method string, path string, query url.Values, heade
 		}
 	}
 	//Handle authentication
-	if c.accessToken != "" {
-		req.Header.Set(headers.Authorization, Bearer+" "+c.accessToken)
+	accessToken := c.currentAccessToken()
+	if accessToken != "" {
+		req.Header.Set(headers.Authorization, Bearer+" "+accessToken)
 	} else {
 		req.SetBasicAuth(c.username, c.password)
 	}
-	requestDump, err := httputil.DumpRequest(req, true)
-	if err != nil {
-		log.Print("Apigee Management API:")
-		log.Print(err)
-	} else {
-		log.Print("Apigee Management API: " + string(requestDump))
-	}
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
-	}
-	if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
-		respBody := new(bytes.Buffer)
-		_, err := respBody.ReadFrom(resp.Body)
-		if err != nil {
-			return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
-		}
-		return nil, &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
-	}
-	return resp.Body, nil
+	logRequest(req, bodyBytes)
+	return req, nil
 }
 
 func (c *Client) requestPath(path string) string {
@@ -209,7 +329,17 @@ func GetMultiPartBuffer(formData map[string]FormData) (*multipart.Writer, *bytes
 	buf := bytes.Buffer{}
 	mp := multipart.NewWriter(&buf)
 	for key, fd := range formData {
-		if fd.Filename != "" {
+		if fd.Data != nil {
+			//Handle files already in memory, Filename is only used to name the part
+			fw, err := mp.CreateFormFile(key, fd.Filename)
+			if err != nil {
+				return nil, nil, err
+			}
+			_, err = fw.Write(fd.Data)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else if fd.Filename != "" {
 			//Handle files
 			file, err := os.Open(fd.Filename)
 			if err != nil {
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"fmt"
+	"net/http"
+)
+
 const (
 	CompanyPath    = "organizations/%s/companies"
 	CompanyPathGet = CompanyPath + "/%s"
@@ -10,3 +15,24 @@ type Company struct {
 	DisplayName string      `json:"displayName"`
 	Attributes  []Attribute `json:"attributes,omitempty"`
 }
+
+func (c *Client) GetCompany(name string) (*Company, error) {
+	retVal := &Company{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(CompanyPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateCompany(in *Company) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(CompanyPath, c.Organization), nil, in, nil)
+}
+
+func (c *Client) UpdateCompany(name string, in *Company) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(CompanyPathGet, c.Organization, name), nil, in, nil)
+}
+
+func (c *Client) DeleteCompany(name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyPathGet, c.Organization, name), nil, nil, nil)
+}
@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	CompanyDeveloperPath    = "organizations/%s/companies/%s/developers"
@@ -25,3 +29,29 @@ func CompanyDeveloperDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) ListCompanyDevelopers(companyName string) ([]CompanyDeveloper, error) {
+	retVal := &CompanyDeveloperList{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(CompanyDeveloperPath, c.Organization, companyName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	for i := range retVal.Developers {
+		retVal.Developers[i].CompanyName = companyName
+	}
+	return retVal.Developers, nil
+}
+
+func (c *Client) SetCompanyDeveloper(in *CompanyDeveloper) error {
+	//Adding and changing the role of a developer are both done by posting a list
+	developers := CompanyDeveloperList{
+		Developers: []CompanyDeveloper{
+			*in,
+		},
+	}
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(CompanyDeveloperPath, c.Organization, in.CompanyName), nil, developers, nil)
+}
+
+func (c *Client) DeleteCompanyDeveloper(companyName string, developerEmail string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(CompanyDeveloperPathGet, c.Organization, companyName, developerEmail), nil, nil, nil)
+}
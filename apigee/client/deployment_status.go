@@ -0,0 +1,136 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	DeploymentStateReady        = "READY"
+	DeploymentStateProgressing  = "PROGRESSING"
+	DeploymentStateError        = "ERROR"
+	EdgeDeploymentStateDeployed = "deployed"
+	EdgeDeploymentStateError    = "error"
+	GooglePodStatusError        = "error"
+)
+
+type DeploymentStatus struct {
+	State  string
+	Errors []string
+}
+
+type EdgeRevisionDeployment struct {
+	State   string                 `json:"state"`
+	Servers []EdgeDeploymentServer `json:"server"`
+}
+type EdgeDeploymentServer struct {
+	Status string            `json:"status"`
+	Types  []string          `json:"type"`
+	UUID   string            `json:"uUID"`
+	Error  string            `json:"error"`
+	Pod    EdgeDeploymentPod `json:"pod"`
+}
+type EdgeDeploymentPod struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+type GoogleRevisionDeployment struct {
+	State          string                          `json:"state"`
+	Errors         []GoogleDeploymentError         `json:"errors"`
+	Pods           []GoogleDeploymentPod           `json:"pods"`
+	RouteConflicts []GoogleDeploymentRouteConflict `json:"routeConflicts"`
+}
+type GoogleDeploymentError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+type GoogleDeploymentPod struct {
+	PodName           string `json:"podName"`
+	DeploymentStatus  string `json:"deploymentStatus"`
+	StatusCode        string `json:"statusCode"`
+	StatusCodeDetails string `json:"statusCodeDetails"`
+}
+type GoogleDeploymentRouteConflict struct {
+	EnvironmentGroup string `json:"environmentGroup"`
+	Description      string `json:"description"`
+}
+
+func (c *Client) GetProxyDeploymentStatus(envName string, proxyName string, revision int) (*DeploymentStatus, error) {
+	return c.getDeploymentStatus(fmt.Sprintf(ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, revision))
+}
+
+func (c *Client) GetSharedFlowDeploymentStatus(envName string, sharedFlowName string, revision int) (*DeploymentStatus, error) {
+	return c.getDeploymentStatus(fmt.Sprintf(SharedFlowDeploymentRevisionPath, c.Organization, envName, sharedFlowName, revision))
+}
+
+func (c *Client) getDeploymentStatus(requestPath string) (*DeploymentStatus, error) {
+	//Normalize Edge and Google into the Google states
+	retVal := &DeploymentStatus{}
+	if c.IsGoogle() {
+		deployment := &GoogleRevisionDeployment{}
+		err := c.jsonRequest(http.MethodGet, requestPath, nil, nil, deployment)
+		if err != nil {
+			return nil, err
+		}
+		retVal.State = deployment.State
+		for _, e := range deployment.Errors {
+			retVal.Errors = append(retVal.Errors, fmt.Sprintf("%d: %s", e.Code, e.Message))
+		}
+		//Name each pod that failed so the runtime instance can be found in the logs
+		for _, pod := range deployment.Pods {
+			if pod.DeploymentStatus != GooglePodStatusError {
+				continue
+			}
+			message := strings.TrimSpace(pod.StatusCode + " " + pod.StatusCodeDetails)
+			if message == "" {
+				message = "deployment failed"
+			}
+			retVal.Errors = append(retVal.Errors, fmt.Sprintf("pod %s: %s", pod.PodName, message))
+		}
+		for _, conflict := range deployment.RouteConflicts {
+			retVal.Errors = append(retVal.Errors, fmt.Sprintf("environment group %s: %s", conflict.EnvironmentGroup, conflict.Description))
+		}
+		if (retVal.State == "") || ((len(retVal.Errors) > 0) && (retVal.State != DeploymentStateError)) {
+			//Errors can be reported before the state catches up
+			if len(retVal.Errors) > 0 {
+				retVal.State = DeploymentStateError
+			} else {
+				retVal.State = DeploymentStateProgressing
+			}
+		}
+		return retVal, nil
+	}
+	deployment := &EdgeRevisionDeployment{}
+	err := c.jsonRequest(http.MethodGet, requestPath, nil, nil, deployment)
+	if err != nil {
+		return nil, err
+	}
+	allDeployed := deployment.State == EdgeDeploymentStateDeployed
+	for _, server := range deployment.Servers {
+		if (server.Status == EdgeDeploymentStateError) || (server.Error != "") {
+			message := server.Error
+			if message == "" {
+				message = "deployment failed"
+			}
+			//Name each message processor or router that failed, along with where it runs
+			location := server.Pod.Name
+			if server.Pod.Region != "" {
+				location += "/" + server.Pod.Region
+			}
+			retVal.Errors = append(retVal.Errors, fmt.Sprintf("%s %s (%s): %s", strings.Join(server.Types, ","), server.UUID, location, message))
+		}
+		if server.Status != EdgeDeploymentStateDeployed {
+			allDeployed = false
+		}
+	}
+	if (len(retVal.Errors) > 0) || (deployment.State == EdgeDeploymentStateError) {
+		retVal.State = DeploymentStateError
+	} else if allDeployed {
+		retVal.State = DeploymentStateReady
+	} else {
+		retVal.State = DeploymentStateProgressing
+	}
+	return retVal, nil
+}
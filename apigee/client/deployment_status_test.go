@@ -0,0 +1,123 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func deploymentStatusServer(t *testing.T, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ApplicationJson)
+		w.Write([]byte(body))
+	}))
+}
+
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newGoogleTestClient(t *testing.T, serverURL string) *Client {
+	//Keep the Google server name so the Google response shapes are used, but send every request to the test server
+	target, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient("", "", "token", true, GoogleApigeeServer, ServerPath, 443, "", "", 0, "org", 0, 0, 1, "", "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetTransport(&redirectTransport{target: target})
+	return c
+}
+
+func TestEdgeDeploymentStatusNamesFailedMessageProcessors(t *testing.T) {
+	server := deploymentStatusServer(t, `{
+		"state": "deployed",
+		"server": [
+			{"status": "deployed", "type": ["message-processor"], "uUID": "mp-1", "pod": {"name": "gateway", "region": "dc-1"}},
+			{"status": "error", "type": ["message-processor"], "uUID": "mp-2", "error": "policy compile failed", "pod": {"name": "gateway", "region": "dc-2"}}
+		]
+	}`)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	status, err := c.GetProxyDeploymentStatus("test", "proxy", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != DeploymentStateError {
+		t.Errorf("expected state %s, got %s", DeploymentStateError, status.State)
+	}
+	if (len(status.Errors) != 1) || (status.Errors[0] != "message-processor mp-2 (gateway/dc-2): policy compile failed") {
+		t.Errorf("unexpected errors %q", status.Errors)
+	}
+}
+
+func TestEdgeDeploymentStatusWaitsForEveryServer(t *testing.T) {
+	server := deploymentStatusServer(t, `{
+		"state": "deployed",
+		"server": [
+			{"status": "deployed", "type": ["message-processor"], "uUID": "mp-1"},
+			{"status": "undeployed", "type": ["message-processor"], "uUID": "mp-2"}
+		]
+	}`)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	status, err := c.GetSharedFlowDeploymentStatus("test", "flow", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != DeploymentStateProgressing {
+		t.Errorf("expected state %s, got %s", DeploymentStateProgressing, status.State)
+	}
+}
+
+func TestGoogleDeploymentStatusNamesFailedPods(t *testing.T) {
+	server := deploymentStatusServer(t, `{
+		"state": "PROGRESSING",
+		"pods": [
+			{"podName": "runtime-1", "deploymentStatus": "deployed"},
+			{"podName": "runtime-2", "deploymentStatus": "error", "statusCode": "500", "statusCodeDetails": "Internal error"}
+		],
+		"routeConflicts": [
+			{"environmentGroup": "group", "description": "base path /v1 is already deployed by other"}
+		]
+	}`)
+	defer server.Close()
+	c := newGoogleTestClient(t, server.URL)
+	status, err := c.GetProxyDeploymentStatus("test", "proxy", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != DeploymentStateError {
+		t.Errorf("expected state %s, got %s", DeploymentStateError, status.State)
+	}
+	expected := []string{
+		"pod runtime-2: 500 Internal error",
+		"environment group group: base path /v1 is already deployed by other",
+	}
+	if strings.Join(status.Errors, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected errors %q, got %q", expected, status.Errors)
+	}
+}
+
+func TestGoogleDeploymentStatusReady(t *testing.T) {
+	server := deploymentStatusServer(t, `{"state": "READY", "pods": [{"podName": "runtime-1", "deploymentStatus": "deployed"}]}`)
+	defer server.Close()
+	c := newGoogleTestClient(t, server.URL)
+	status, err := c.GetSharedFlowDeploymentStatus("test", "flow", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (status.State != DeploymentStateReady) || (len(status.Errors) != 0) {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
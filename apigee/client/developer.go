@@ -1,5 +1,12 @@
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
 const (
 	DeveloperPath    = "organizations/%s/developers"
 	DeveloperPathGet = DeveloperPath + "/%s"
@@ -12,3 +19,46 @@ type Developer struct {
 	UserName   string      `json:"userName"`
 	Attributes []Attribute `json:"attributes,omitempty"`
 }
+type GoogleDeveloperList struct {
+	Developers []Developer `json:"developer"`
+}
+
+func (c *Client) GetDeveloper(email string) (*Developer, error) {
+	retVal := &Developer{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(DeveloperPathGet, c.Organization, email), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateDeveloper(in *Developer) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(DeveloperPath, c.Organization), nil, in, nil)
+}
+
+func (c *Client) UpdateDeveloper(email string, in *Developer) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(DeveloperPathGet, c.Organization, email), nil, in, nil)
+}
+
+func (c *Client) DeleteDeveloper(email string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(DeveloperPathGet, c.Organization, email), nil, nil, nil)
+}
+
+func (c *Client) ListDevelopers() ([]string, error) {
+	requestPath := fmt.Sprintf(DeveloperPath, c.Organization)
+	if !c.IsGoogle() {
+		return c.ListByStartKey(requestPath, nil, DecodeNamePage)
+	}
+	return c.ListByStartKey(requestPath, nil, func(body io.Reader) ([]string, error) {
+		page := &GoogleDeveloperList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return nil, err
+		}
+		retVal := []string{}
+		for _, developer := range page.Developers {
+			retVal = append(retVal, developer.Email)
+		}
+		return retVal, nil
+	})
+}
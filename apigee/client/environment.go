@@ -0,0 +1,85 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	EnvironmentPath    = "organizations/%s/environments"
+	EnvironmentPathGet = EnvironmentPath + "/%s"
+)
+
+type Environment struct {
+	Name           string                 `json:"name"`
+	DisplayName    string                 `json:"displayName,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Properties     *EnvironmentProperties `json:"properties,omitempty"`
+	DeploymentType string                 `json:"deploymentType,omitempty"`
+	ApiProxyType   string                 `json:"apiProxyType,omitempty"`
+	NodeConfig     *EnvironmentNodeConfig `json:"nodeConfig,omitempty"`
+	State          string                 `json:"state,omitempty"`
+}
+type EnvironmentProperties struct {
+	Properties []Attribute `json:"property"`
+}
+type EnvironmentNodeConfig struct {
+	//Google returns the counts as strings since they are int64
+	MinNodeCount              string `json:"minNodeCount,omitempty"`
+	MaxNodeCount              string `json:"maxNodeCount,omitempty"`
+	CurrentAggregateNodeCount string `json:"currentAggregateNodeCount,omitempty"`
+}
+
+func (c *Client) GetEnvironment(name string) (*Environment, error) {
+	retVal := &Environment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateEnvironment(in *Environment) (*LongRunningOperation, error) {
+	//Google creates environments asynchronously and returns an operation to poll
+	requestPath := fmt.Sprintf(EnvironmentPath, c.Organization)
+	if !c.IsGoogle() {
+		return nil, c.jsonRequest(http.MethodPost, requestPath, nil, in, nil)
+	}
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPost, requestPath, nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) UpdateEnvironment(name string, in *Environment) (*LongRunningOperation, error) {
+	requestPath := fmt.Sprintf(EnvironmentPathGet, c.Organization, name)
+	if !c.IsGoogle() {
+		return nil, c.jsonRequest(http.MethodPut, requestPath, nil, in, nil)
+	}
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPut, requestPath, nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	//Some changes are applied right away, in which case the environment itself is returned instead of an operation
+	if !strings.Contains(retVal.Name, OperationPathSegment) {
+		return nil, nil
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeleteEnvironment(name string) (*LongRunningOperation, error) {
+	requestPath := fmt.Sprintf(EnvironmentPathGet, c.Organization, name)
+	if !c.IsGoogle() {
+		return nil, c.jsonRequest(http.MethodDelete, requestPath, nil, nil, nil)
+	}
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodDelete, requestPath, nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	EnvironmentDeploymentPath = "organizations/%s/environments/%s/deployments"
+)
+
+type EnvironmentDeployments struct {
+	EnvironmentName string                        `json:"name"`
+	Deployments     []EnvironmentBundleDeployment `json:"aPIProxy"`
+}
+type EnvironmentBundleDeployment struct {
+	Name      string                          `json:"name"`
+	Revisions []EnvironmentRevisionDeployment `json:"revision"`
+}
+type EnvironmentRevisionDeployment struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+func (c *Client) GetEnvironmentDeployments(envName string, sharedFlows bool) (*EnvironmentDeployments, error) {
+	//Edge returns shared flows using the same structure as proxies
+	requestQuery := url.Values{
+		"sharedFlows": []string{strconv.FormatBool(sharedFlows)},
+	}
+	retVal := &EnvironmentDeployments{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentDeploymentPath, c.Organization, envName), requestQuery, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleEnvironmentDeployments(envName string, sharedFlows bool) (*GoogleProxyEnvironmentDeployment, error) {
+	requestQuery := url.Values{
+		"sharedFlows": []string{strconv.FormatBool(sharedFlows)},
+	}
+	retVal := &GoogleProxyEnvironmentDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentDeploymentPath, c.Organization, envName), requestQuery, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
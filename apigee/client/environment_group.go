@@ -0,0 +1,124 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	EnvironmentGroupPath              = "organizations/%s/envgroups"
+	EnvironmentGroupPathGet           = EnvironmentGroupPath + "/%s"
+	EnvironmentGroupAttachmentPath    = EnvironmentGroupPathGet + "/attachments"
+	EnvironmentGroupAttachmentPathGet = EnvironmentGroupAttachmentPath + "/%s"
+)
+
+type EnvironmentGroup struct {
+	Name      string   `json:"name"`
+	Hostnames []string `json:"hostnames"`
+	State     string   `json:"state,omitempty"`
+}
+
+type EnvironmentGroupAttachment struct {
+	EnvironmentGroupName string `json:"-"`
+	//Name is generated by Google so attachments are identified by environment instead
+	Name            string `json:"name,omitempty"`
+	EnvironmentName string `json:"environment"`
+}
+
+type EnvironmentGroupAttachmentList struct {
+	Attachments   []EnvironmentGroupAttachment `json:"environmentGroupAttachments"`
+	NextPageToken string                       `json:"nextPageToken"`
+}
+
+func (c *EnvironmentGroupAttachment) EnvironmentGroupAttachmentEncodeId() string {
+	return c.EnvironmentGroupName + IdSeparator + c.EnvironmentName
+}
+
+func EnvironmentGroupAttachmentDecodeId(s string) (string, string) {
+	tokens := strings.Split(s, IdSeparator)
+	return tokens[0], tokens[1]
+}
+
+func (c *Client) GetEnvironmentGroup(name string) (*EnvironmentGroup, error) {
+	retVal := &EnvironmentGroup{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentGroupPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateEnvironmentGroup(in *EnvironmentGroup) (*LongRunningOperation, error) {
+	requestQuery := url.Values{
+		"name": []string{in.Name},
+	}
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPost, fmt.Sprintf(EnvironmentGroupPath, c.Organization), requestQuery, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) UpdateEnvironmentGroupHostnames(name string, hostnames []string) (*LongRunningOperation, error) {
+	requestQuery := url.Values{
+		"updateMask": []string{"hostnames"},
+	}
+	in := &EnvironmentGroup{
+		Hostnames: hostnames,
+	}
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPatch, fmt.Sprintf(EnvironmentGroupPathGet, c.Organization, name), requestQuery, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeleteEnvironmentGroup(name string) (*LongRunningOperation, error) {
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodDelete, fmt.Sprintf(EnvironmentGroupPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ListEnvironmentGroupAttachments(groupName string) ([]EnvironmentGroupAttachment, error) {
+	retVal := []EnvironmentGroupAttachment{}
+	err := c.ListByPageToken(fmt.Sprintf(EnvironmentGroupAttachmentPath, c.Organization, groupName), nil, func(body io.Reader) (string, error) {
+		page := &EnvironmentGroupAttachmentList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return "", err
+		}
+		retVal = append(retVal, page.Attachments...)
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateEnvironmentGroupAttachment(in *EnvironmentGroupAttachment) (*LongRunningOperation, error) {
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPost, fmt.Sprintf(EnvironmentGroupAttachmentPath, c.Organization, in.EnvironmentGroupName), nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeleteEnvironmentGroupAttachment(groupName string, attachmentName string) (*LongRunningOperation, error) {
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodDelete, fmt.Sprintf(EnvironmentGroupAttachmentPathGet, c.Organization, groupName, attachmentName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
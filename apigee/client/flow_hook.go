@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	FlowHookPath    = "organizations/%s/environments/%s/flowhooks"
+	FlowHookPathGet = FlowHookPath + "/%s"
+)
+
+var (
+	FlowHookPoints = []string{"PreProxyFlowHook", "PostProxyFlowHook", "PreTargetFlowHook", "PostTargetFlowHook"}
+)
+
+type FlowHook struct {
+	EnvironmentName string `json:"-"`
+	FlowHookPoint   string `json:"-"`
+	SharedFlowName  string `json:"sharedFlow"`
+	ContinueOnError bool   `json:"continueOnError"`
+	//Only supported by Google
+	Description string `json:"description,omitempty"`
+}
+
+func (c *FlowHook) FlowHookEncodeId() string {
+	return c.EnvironmentName + IdSeparator + c.FlowHookPoint
+}
+
+func FlowHookDecodeId(s string) (string, string) {
+	tokens := strings.Split(s, IdSeparator)
+	return tokens[0], tokens[1]
+}
+
+func (c *Client) GetFlowHook(envName string, flowHookPoint string) (*FlowHook, error) {
+	retVal := &FlowHook{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(FlowHookPathGet, c.Organization, envName, flowHookPoint), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	retVal.FlowHookPoint = flowHookPoint
+	return retVal, nil
+}
+
+func (c *Client) AttachFlowHook(in *FlowHook) error {
+	//Edge attaches with POST while Google replaces the flow hook with PUT, either way attaching again is harmless
+	method := http.MethodPost
+	if c.IsGoogle() {
+		method = http.MethodPut
+	}
+	return c.idempotentJsonRequest(method, fmt.Sprintf(FlowHookPathGet, c.Organization, in.EnvironmentName, in.FlowHookPoint), nil, in, nil)
+}
+
+func (c *Client) DetachFlowHook(envName string, flowHookPoint string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(FlowHookPathGet, c.Organization, envName, flowHookPoint), nil, nil, nil)
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	InstancePath              = "organizations/%s/instances"
+	InstancePathGet           = InstancePath + "/%s"
+	InstanceAttachmentPath    = InstancePathGet + "/attachments"
+	InstanceAttachmentPathGet = InstanceAttachmentPath + "/%s"
+)
+
+type Instance struct {
+	Name             string `json:"name"`
+	DisplayName      string `json:"displayName"`
+	Location         string `json:"location"`
+	Host             string `json:"host"`
+	Port             string `json:"port"`
+	PeeringCidrRange string `json:"peeringCidrRange"`
+	IpRange          string `json:"ipRange"`
+	State            string `json:"state"`
+}
+type InstanceList struct {
+	Instances     []Instance `json:"instances"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+type InstanceAttachment struct {
+	InstanceName string `json:"-"`
+	//Name is generated by Google so attachments are identified by environment instead
+	Name            string `json:"name,omitempty"`
+	EnvironmentName string `json:"environment"`
+}
+type InstanceAttachmentList struct {
+	Attachments   []InstanceAttachment `json:"attachments"`
+	NextPageToken string               `json:"nextPageToken"`
+}
+
+func (c *InstanceAttachment) InstanceAttachmentEncodeId() string {
+	return c.InstanceName + IdSeparator + c.EnvironmentName
+}
+
+func InstanceAttachmentDecodeId(s string) (string, string) {
+	tokens := strings.Split(s, IdSeparator)
+	return tokens[0], tokens[1]
+}
+
+func (c *Client) ListInstances() ([]Instance, error) {
+	retVal := []Instance{}
+	err := c.ListByPageToken(fmt.Sprintf(InstancePath, c.Organization), nil, func(body io.Reader) (string, error) {
+		page := &InstanceList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return "", err
+		}
+		retVal = append(retVal, page.Instances...)
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ListInstanceAttachments(instanceName string) ([]InstanceAttachment, error) {
+	retVal := []InstanceAttachment{}
+	err := c.ListByPageToken(fmt.Sprintf(InstanceAttachmentPath, c.Organization, instanceName), nil, func(body io.Reader) (string, error) {
+		page := &InstanceAttachmentList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return "", err
+		}
+		retVal = append(retVal, page.Attachments...)
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateInstanceAttachment(in *InstanceAttachment) (*LongRunningOperation, error) {
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodPost, fmt.Sprintf(InstanceAttachmentPath, c.Organization, in.InstanceName), nil, in, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeleteInstanceAttachment(instanceName string, attachmentName string) (*LongRunningOperation, error) {
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodDelete, fmt.Sprintf(InstanceAttachmentPathGet, c.Organization, instanceName, attachmentName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	KeystorePath    = "organizations/%s/environments/%s/keystores"
@@ -20,3 +24,21 @@ func KeystoreDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetKeystore(envName string, name string) (*Keystore, error) {
+	retVal := &Keystore{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(KeystorePathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateKeystore(in *Keystore) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(KeystorePath, c.Organization, in.EnvironmentName), nil, in, nil)
+}
+
+func (c *Client) DeleteKeystore(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(KeystorePathGet, c.Organization, envName, name), nil, nil, nil)
+}
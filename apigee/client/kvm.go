@@ -1,6 +1,12 @@
 package client
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
 
 const (
 	OrganizationKVMPath           = "organizations/%s/keyvaluemaps"
@@ -44,3 +50,133 @@ func KVMDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) ListEnvironmentKVMs(envName string) ([]string, error) {
+	retVal := []string{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentKVMPath, c.Organization, envName), nil, nil, &retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ListEnvironmentKVMEntries(envName string, name string) ([]Attribute, error) {
+	retVal := []Attribute{}
+	err := c.ListByPageToken(fmt.Sprintf(EnvironmentKVMPathEntries, c.Organization, envName, name), nil, func(body io.Reader) (string, error) {
+		page := &KVMEntries{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return "", err
+		}
+		retVal = append(retVal, page.KeyValueEntries...)
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetOrganizationKVM(name string) (*KVM, error) {
+	retVal := &KVM{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(OrganizationKVMPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateOrganizationKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(OrganizationKVMPath, c.Organization), nil, in, nil)
+}
+
+func (c *Client) UpdateOrganizationKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(OrganizationKVMPathGet, c.Organization, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteOrganizationKVM(name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(OrganizationKVMPathGet, c.Organization, name), nil, nil, nil)
+}
+
+func (c *Client) CreateOrganizationKVMEntry(name string, entry *Attribute) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(OrganizationKVMPathEntries, c.Organization, name), nil, entry, nil)
+}
+
+func (c *Client) UpdateOrganizationKVMEntry(name string, entry *Attribute) error {
+	//Changing an entry to a known value is safe to repeat
+	return c.idempotentJsonRequest(http.MethodPost, fmt.Sprintf(OrganizationKVMPathEntriesGet, c.Organization, name, entry.Name), nil, entry, nil)
+}
+
+func (c *Client) DeleteOrganizationKVMEntry(name string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(OrganizationKVMPathEntriesGet, c.Organization, name, key), nil, nil, nil)
+}
+
+func (c *Client) GetEnvironmentKVM(envName string, name string) (*KVM, error) {
+	retVal := &KVM{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentKVMPathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateEnvironmentKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(EnvironmentKVMPath, c.Organization, in.EnvironmentName), nil, in, nil)
+}
+
+func (c *Client) UpdateEnvironmentKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(EnvironmentKVMPathGet, c.Organization, in.EnvironmentName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteEnvironmentKVM(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(EnvironmentKVMPathGet, c.Organization, envName, name), nil, nil, nil)
+}
+
+func (c *Client) CreateEnvironmentKVMEntry(envName string, name string, entry *Attribute) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(EnvironmentKVMPathEntries, c.Organization, envName, name), nil, entry, nil)
+}
+
+func (c *Client) UpdateEnvironmentKVMEntry(envName string, name string, entry *Attribute) error {
+	//Changing an entry to a known value is safe to repeat
+	return c.idempotentJsonRequest(http.MethodPost, fmt.Sprintf(EnvironmentKVMPathEntriesGet, c.Organization, envName, name, entry.Name), nil, entry, nil)
+}
+
+func (c *Client) DeleteEnvironmentKVMEntry(envName string, name string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(EnvironmentKVMPathEntriesGet, c.Organization, envName, name, key), nil, nil, nil)
+}
+
+func (c *Client) GetProxyKVM(proxyName string, name string) (*KVM, error) {
+	retVal := &KVM{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyKVMPathGet, c.Organization, proxyName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.ProxyName = proxyName
+	return retVal, nil
+}
+
+func (c *Client) CreateProxyKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(ProxyKVMPath, c.Organization, in.ProxyName), nil, in, nil)
+}
+
+func (c *Client) UpdateProxyKVM(in *KVM) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(ProxyKVMPathGet, c.Organization, in.ProxyName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteProxyKVM(proxyName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyKVMPathGet, c.Organization, proxyName, name), nil, nil, nil)
+}
+
+func (c *Client) CreateProxyKVMEntry(proxyName string, name string, entry *Attribute) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(ProxyKVMPathEntries, c.Organization, proxyName, name), nil, entry, nil)
+}
+
+func (c *Client) UpdateProxyKVMEntry(proxyName string, name string, entry *Attribute) error {
+	//Changing an entry to a known value is safe to repeat
+	return c.idempotentJsonRequest(http.MethodPost, fmt.Sprintf(ProxyKVMPathEntriesGet, c.Organization, proxyName, name, entry.Name), nil, entry, nil)
+}
+
+func (c *Client) DeleteProxyKVMEntry(proxyName string, name string, key string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyKVMPathEntriesGet, c.Organization, proxyName, name, key), nil, nil, nil)
+}
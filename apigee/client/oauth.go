@@ -1,7 +1,22 @@
 package client
 
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-http-utils/headers"
+)
+
 const (
 	OauthTokenPath = "oauth/token"
+	//Renew access tokens this long before they actually expire to allow for clock skew and slow requests
+	TokenExpiryMargin = 2 * time.Minute
 )
 
 type OauthToken struct {
@@ -11,3 +26,171 @@ type OauthToken struct {
 	ExpiresIn    int    `json:"expires_in"`
 	Scope        string `json:"scope"`
 }
+
+func (c *Client) usesOauth() bool {
+	return (c.oauthServer != "") || (c.serviceAccountKey != nil)
+}
+
+func (c *Client) currentAccessToken() string {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	return c.accessToken
+}
+
+func (c *Client) oauthTokenURL() string {
+	var scheme string
+	if c.useSSL {
+		scheme = HTTPS
+	} else {
+		scheme = HTTP
+	}
+	if c.oauthServerPath != "" {
+		return fmt.Sprintf("%s://%s:%d/%s/%s", scheme, c.oauthServer, c.oauthPort, c.oauthServerPath, OauthTokenPath)
+	}
+	return fmt.Sprintf("%s://%s:%d/%s", scheme, c.oauthServer, c.oauthPort, OauthTokenPath)
+}
+
+func (c *Client) authenticate() error {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	return c.newTokenGrant()
+}
+
+func (c *Client) reauthenticate() error {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	return c.renewAccessToken()
+}
+
+func (c *Client) newTokenGrant() error {
+	if c.serviceAccountKey != nil {
+		return c.serviceAccountGrant()
+	}
+	return c.passwordGrant()
+}
+
+func (c *Client) ensureAccessToken() error {
+	if !c.usesOauth() {
+		return nil
+	}
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	//Tokens without an expiry are assumed to be valid for the life of the process
+	if c.tokenExpiry.IsZero() || time.Now().Before(c.tokenExpiry) {
+		return nil
+	}
+	return c.renewAccessToken()
+}
+
+func (c *Client) usesOneTimeCredentials() bool {
+	//Passcodes and MFA tokens are only valid for a single token grant
+	return (c.serviceAccountKey == nil) && ((c.passcode != "") || (c.mfaToken != ""))
+}
+
+func (c *Client) renewAccessToken() error {
+	if c.refreshToken != "" {
+		err := c.refreshTokenGrant()
+		if err == nil {
+			return nil
+		}
+		if c.usesOneTimeCredentials() {
+			return fmt.Errorf("unable to refresh the access token and the passcode or mfa_token cannot be reused, provide a new one: %v", err)
+		}
+		//Refresh tokens expire too, so fall back to obtaining a brand new token
+		log.Print("Apigee Management API: Unable to refresh access token, obtaining a new one")
+	} else if c.usesOneTimeCredentials() {
+		return fmt.Errorf("the access token expired without a refresh token and the passcode or mfa_token cannot be reused, provide a new one")
+	}
+	return c.newTokenGrant()
+}
+
+func (c *Client) passwordGrant() error {
+	log.Print("Apigee Management API: Obtaining access token...")
+	requestURL := c.oauthTokenURL()
+	var requestForm url.Values
+	if c.passcode != "" {
+		//One-time passcodes replace the username and password entirely
+		requestForm = url.Values{
+			"grant_type":    []string{"password"},
+			"response_type": []string{"token"},
+			"passcode":      []string{c.passcode},
+		}
+	} else {
+		requestForm = url.Values{
+			"grant_type": []string{"password"},
+			"username":   []string{c.username},
+			"password":   []string{c.password},
+		}
+		if c.mfaToken != "" {
+			requestURL = requestURL + "?" + url.Values{"mfa_token": []string{c.mfaToken}}.Encode()
+		}
+	}
+	return c.requestOauthToken(requestURL, requestForm, c.oauthClientAuthorization())
+}
+
+func (c *Client) oauthClientAuthorization() string {
+	if c.oauthClientId == "" {
+		return Basic + " " + SSOClientCredentials
+	}
+	return Basic + " " + base64.StdEncoding.EncodeToString([]byte(c.oauthClientId+":"+c.oauthClientSecret))
+}
+
+func (c *Client) refreshTokenGrant() error {
+	log.Print("Apigee Management API: Refreshing access token...")
+	requestForm := url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"refresh_token": []string{c.refreshToken},
+	}
+	return c.requestOauthToken(c.oauthTokenURL(), requestForm, c.oauthClientAuthorization())
+}
+
+func (c *Client) requestOauthToken(requestURL string, requestForm url.Values, authorization string) error {
+	requestBody := requestForm.Encode()
+	req, err := http.NewRequest(http.MethodPost, requestURL, bytes.NewBufferString(requestBody))
+	if err != nil {
+		return err
+	}
+	if authorization != "" {
+		req.Header.Set(headers.Authorization, authorization)
+	}
+	req.Header.Set(headers.ContentType, FormEncoded)
+	logRequest(req, []byte(requestBody))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	defer resp.Body.Close()
+	if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
+		respBody := new(bytes.Buffer)
+		_, err := respBody.ReadFrom(resp.Body)
+		if err != nil {
+			return &RequestError{StatusCode: resp.StatusCode, Err: err}
+		}
+		return &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
+	}
+	//Parse body to extract access_token
+	token := &OauthToken{}
+	err = json.NewDecoder(resp.Body).Decode(token)
+	if err != nil {
+		return err
+	}
+	log.Printf("Apigee Management API: Received access token, expires in %d seconds", token.ExpiresIn)
+	//Inject token as access_token for client for all future calls
+	c.accessToken = token.AccessToken
+	//Some servers only issue a refresh token on the initial grant so keep the previous one
+	if token.RefreshToken != "" {
+		c.refreshToken = token.RefreshToken
+	}
+	if token.ExpiresIn > 0 {
+		//Track when the token should be renewed rather than when it actually expires
+		expiresIn := time.Duration(token.ExpiresIn) * time.Second
+		margin := TokenExpiryMargin
+		if margin > expiresIn/2 {
+			margin = expiresIn / 2
+		}
+		c.tokenExpiry = time.Now().Add(expiresIn - margin)
+	} else {
+		c.tokenExpiry = time.Time{}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeOauthServer struct {
+	mutex          sync.Mutex
+	passwordGrants int
+	refreshGrants  int
+	refreshToken   string
+	mfaTokens      []string
+}
+
+func (f *fakeOauthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if r.URL.Path != "/"+OauthTokenPath {
+		w.Write([]byte("{}"))
+		return
+	}
+	r.ParseForm()
+	token := OauthToken{
+		TokenType: Bearer,
+		ExpiresIn: 3600,
+	}
+	switch r.Form.Get("grant_type") {
+	case "password":
+		f.passwordGrants++
+		f.mfaTokens = append(f.mfaTokens, r.URL.Query().Get("mfa_token"))
+		token.AccessToken = "token-" + strconv.Itoa(f.passwordGrants)
+		token.RefreshToken = f.refreshToken
+	case "refresh_token":
+		//Refresh tokens are rejected so the client has to fall back
+		f.refreshGrants++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	json.NewEncoder(w).Encode(token)
+}
+
+func newOauthTestClient(t *testing.T, f *fakeOauthServer, mfaToken string, passcode string) *Client {
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	c, err := NewClient("user", "pass", "", false, u.Hostname(), ServerPath, port, u.Hostname(), "", port, "org", 0, 0, 0, "", "", "", mfaToken, passcode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func expireAccessToken(c *Client) {
+	c.tokenMutex.Lock()
+	c.tokenExpiry = time.Now().Add(-time.Second)
+	c.tokenMutex.Unlock()
+}
+
+func TestPasswordGrantIsRepeatedWhenRefreshFails(t *testing.T) {
+	f := &fakeOauthServer{refreshToken: "refresh"}
+	c := newOauthTestClient(t, f, "", "")
+	expireAccessToken(c)
+	err := c.jsonRequest(http.MethodGet, "organizations/org", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (f.refreshGrants != 1) || (f.passwordGrants != 2) {
+		t.Errorf("expected a failed refresh followed by a new password grant, got %d refreshes and %d password grants", f.refreshGrants, f.passwordGrants)
+	}
+}
+
+func TestOneTimeCredentialsAreNotReused(t *testing.T) {
+	tests := []struct {
+		name         string
+		mfaToken     string
+		passcode     string
+		refreshToken string
+	}{
+		{"mfa token without refresh token", "123456", "", ""},
+		{"mfa token with rejected refresh token", "123456", "", "refresh"},
+		{"passcode without refresh token", "", "abcdef", ""},
+	}
+	for _, test := range tests {
+		f := &fakeOauthServer{refreshToken: test.refreshToken}
+		c := newOauthTestClient(t, f, test.mfaToken, test.passcode)
+		expireAccessToken(c)
+		err := c.jsonRequest(http.MethodGet, "organizations/org", nil, nil, nil)
+		if (err == nil) || !strings.Contains(err.Error(), "cannot be reused") {
+			t.Errorf("%s: expected an explicit error, got %v", test.name, err)
+		}
+		if f.passwordGrants != 1 {
+			t.Errorf("%s: expected the one time credential to be sent once, got %d password grants", test.name, f.passwordGrants)
+		}
+	}
+}
+
+func TestMfaTokenIsSentButNotLogged(t *testing.T) {
+	logs := &bytes.Buffer{}
+	log.SetOutput(logs)
+	defer log.SetOutput(os.Stderr)
+	f := &fakeOauthServer{}
+	newOauthTestClient(t, f, "123456", "")
+	if (len(f.mfaTokens) != 1) || (f.mfaTokens[0] != "123456") {
+		t.Errorf("expected the mfa_token to be sent, got %v", f.mfaTokens)
+	}
+	if strings.Contains(logs.String(), "123456") {
+		t.Errorf("mfa_token was logged: %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "mfa_token="+Redacted) {
+		t.Errorf("expected a redacted mfa_token in the log: %s", logs.String())
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	if got := redactQuery("count=10&startKey=a%20b"); got != "count=10&startKey=a%20b" {
+		t.Errorf("expected a query without credentials to be unchanged, got %s", got)
+	}
+	got := redactQuery("mfa_token=123456&name=test&access_token=secret")
+	if strings.Contains(got, "123456") || strings.Contains(got, "secret") || !strings.Contains(got, "name=test") {
+		t.Errorf("expected credentials to be redacted, got %s", got)
+	}
+}
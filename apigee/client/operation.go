@@ -0,0 +1,35 @@
+package client
+
+import (
+	"net/http"
+)
+
+const (
+	OperationPathSegment = "/operations/"
+)
+
+type LongRunningOperation struct {
+	Name     string            `json:"name"`
+	Done     bool              `json:"done"`
+	Error    *OperationError   `json:"error,omitempty"`
+	Metadata OperationMetadata `json:"metadata"`
+}
+type OperationError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+type OperationMetadata struct {
+	OperationType string `json:"operationType"`
+	State         string `json:"state"`
+	TargetName    string `json:"targetResourceName"`
+}
+
+func (c *Client) GetOperation(name string) (*LongRunningOperation, error) {
+	//The operation name is already the path relative to the server path
+	retVal := &LongRunningOperation{}
+	err := c.jsonRequest(http.MethodGet, name, nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
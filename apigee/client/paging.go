@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	//Largest page the Edge list APIs will return when using startKey/count
+	EdgePageSize = 1000
+	//Largest page most Google list APIs will return when using pageToken/pageSize
+	GooglePageSize = 100
+)
+
+type KeyPageDecoder func(body io.Reader) ([]string, error)
+type TokenPageDecoder func(body io.Reader) (string, error)
+
+func DecodeNamePage(body io.Reader) ([]string, error) {
+	retVal := []string{}
+	err := json.NewDecoder(body).Decode(&retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ListByStartKey(requestPath string, query url.Values, decode KeyPageDecoder) ([]string, error) {
+	retVal := []string{}
+	startKey := ""
+	for {
+		pageQuery := copyQuery(query)
+		pageQuery.Set("count", strconv.Itoa(EdgePageSize))
+		if startKey != "" {
+			pageQuery.Set("startKey", startKey)
+		}
+		body, err := c.HttpRequest(http.MethodGet, requestPath, pageQuery, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		page, err := decode(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+		pageSize := len(page)
+		//The startKey is inclusive so every page after the first repeats the last key of the previous page
+		if (startKey != "") && (len(page) > 0) && (page[0] == startKey) {
+			page = page[1:]
+		}
+		retVal = append(retVal, page...)
+		//Only a full page means there may be more, which also stops servers that ignore count from looping forever
+		if (pageSize != EdgePageSize) || (len(page) == 0) {
+			return retVal, nil
+		}
+		startKey = page[len(page)-1]
+	}
+}
+
+func (c *Client) ListByPageToken(requestPath string, query url.Values, decode TokenPageDecoder) error {
+	pageToken := ""
+	for {
+		pageQuery := copyQuery(query)
+		pageQuery.Set("pageSize", strconv.Itoa(GooglePageSize))
+		if pageToken != "" {
+			pageQuery.Set("pageToken", pageToken)
+		}
+		body, err := c.HttpRequest(http.MethodGet, requestPath, pageQuery, nil, nil)
+		if err != nil {
+			return err
+		}
+		nextPageToken, err := decode(body)
+		body.Close()
+		if err != nil {
+			return err
+		}
+		//Guard against a server that keeps returning the same token
+		if (nextPageToken == "") || (nextPageToken == pageToken) {
+			return nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func copyQuery(query url.Values) url.Values {
+	retVal := url.Values{}
+	for key, values := range query {
+		retVal[key] = append([]string{}, values...)
+	}
+	return retVal
+}
@@ -0,0 +1,254 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func edgeNames(count int) []string {
+	retVal := []string{}
+	for i := 0; i < count; i++ {
+		retVal = append(retVal, fmt.Sprintf("name-%05d", i))
+	}
+	return retVal
+}
+
+func edgePagingServer(t *testing.T, names []string, ignoreCount bool) (*httptest.Server, *int) {
+	return pagingServer(t, "", names, ignoreCount, func(page []string) interface{} {
+		return page
+	})
+}
+
+func pagingServer(t *testing.T, expectedPath string, names []string, ignoreCount bool, wrap func(page []string) interface{}) (*httptest.Server, *int) {
+	requests := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if (expectedPath != "") && (r.URL.Path != expectedPath) {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		count, err := strconv.Atoi(r.URL.Query().Get("count"))
+		if err != nil || (count != EdgePageSize) {
+			t.Errorf("expected count %d, got %q", EdgePageSize, r.URL.Query().Get("count"))
+		}
+		//Edge starts the page at the startKey itself, so consecutive pages overlap by one name
+		start := 0
+		if startKey := r.URL.Query().Get("startKey"); startKey != "" {
+			for i, name := range names {
+				if name == startKey {
+					start = i
+					break
+				}
+			}
+		}
+		end := start + count
+		if ignoreCount || (end > len(names)) {
+			end = len(names)
+		}
+		json.NewEncoder(w).Encode(wrap(names[start:end]))
+	}))
+	return server, requests
+}
+
+func TestListByStartKeyDropsOverlap(t *testing.T) {
+	tests := []struct {
+		total            int
+		expectedRequests int
+	}{
+		{0, 1},
+		{10, 1},
+		{EdgePageSize, 2},
+		{EdgePageSize*2 + 500, 3},
+	}
+	for _, test := range tests {
+		names := edgeNames(test.total)
+		server, requests := edgePagingServer(t, names, false)
+		c := newTestClient(t, server.URL, 0, 0)
+		got, err := c.ListByStartKey("list", nil, DecodeNamePage)
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(names) {
+			t.Errorf("%d names: expected every name exactly once, got %d", test.total, len(got))
+			continue
+		}
+		for i := range names {
+			if got[i] != names[i] {
+				t.Errorf("%d names: expected %s at %d, got %s", test.total, names[i], i, got[i])
+				break
+			}
+		}
+		if *requests != test.expectedRequests {
+			t.Errorf("%d names: expected %d requests, got %d", test.total, test.expectedRequests, *requests)
+		}
+	}
+}
+
+func TestListByStartKeyStopsWhenCountIgnored(t *testing.T) {
+	names := edgeNames(EdgePageSize + 1)
+	server, requests := edgePagingServer(t, names, true)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 0)
+	got, err := c.ListByStartKey("list", nil, DecodeNamePage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(got) != len(names)) || (*requests != 1) {
+		t.Errorf("expected %d names from 1 request, got %d names from %d requests", len(names), len(got), *requests)
+	}
+}
+
+func TestTypedListsPageThroughEveryName(t *testing.T) {
+	edgeNamePage := func(page []string) interface{} {
+		return page
+	}
+	tests := []struct {
+		name   string
+		google bool
+		path   string
+		wrap   func(page []string) interface{}
+		list   func(c *Client) ([]string, error)
+	}{
+		{"edge developers", false, "/v1/organizations/org/developers", edgeNamePage, func(c *Client) ([]string, error) {
+			return c.ListDevelopers()
+		}},
+		{"google developers", true, "/v1/organizations/org/developers", func(page []string) interface{} {
+			retVal := GoogleDeveloperList{Developers: []Developer{}}
+			for _, name := range page {
+				retVal.Developers = append(retVal.Developers, Developer{Email: name})
+			}
+			return retVal
+		}, func(c *Client) ([]string, error) {
+			return c.ListDevelopers()
+		}},
+		{"edge developer apps", false, "/v1/organizations/org/developers/dev@example.com/apps", edgeNamePage, func(c *Client) ([]string, error) {
+			return c.ListDeveloperApps("dev@example.com")
+		}},
+		{"google developer apps", true, "/v1/organizations/org/developers/dev@example.com/apps", func(page []string) interface{} {
+			retVal := GoogleAppList{Apps: []App{}}
+			for _, name := range page {
+				retVal.Apps = append(retVal.Apps, App{Name: name})
+			}
+			return retVal
+		}, func(c *Client) ([]string, error) {
+			return c.ListDeveloperApps("dev@example.com")
+		}},
+		{"edge company apps", false, "/v1/organizations/org/companies/acme/apps", edgeNamePage, func(c *Client) ([]string, error) {
+			return c.ListCompanyApps("acme")
+		}},
+		{"edge products", false, "/v1/organizations/org/apiproducts", edgeNamePage, func(c *Client) ([]string, error) {
+			return c.ListProducts()
+		}},
+		{"google products", true, "/v1/organizations/org/apiproducts", func(page []string) interface{} {
+			retVal := GoogleProductList{Products: []Product{}}
+			for _, name := range page {
+				retVal.Products = append(retVal.Products, Product{Name: name})
+			}
+			return retVal
+		}, func(c *Client) ([]string, error) {
+			return c.ListProducts()
+		}},
+		{"edge shared flows", false, "/v1/organizations/org/sharedflows", edgeNamePage, func(c *Client) ([]string, error) {
+			return c.ListSharedFlows()
+		}},
+		{"google shared flows", true, "/v1/organizations/org/sharedflows", func(page []string) interface{} {
+			retVal := GoogleSharedFlowList{SharedFlows: []SharedFlow{}}
+			for _, name := range page {
+				retVal.SharedFlows = append(retVal.SharedFlows, SharedFlow{Name: name})
+			}
+			return retVal
+		}, func(c *Client) ([]string, error) {
+			return c.ListSharedFlows()
+		}},
+	}
+	for _, test := range tests {
+		names := edgeNames(EdgePageSize*2 + 7)
+		server, requests := pagingServer(t, test.path, names, false, test.wrap)
+		var c *Client
+		if test.google {
+			c = newGoogleTestClient(t, server.URL)
+		} else {
+			c = newTestClient(t, server.URL, 0, 0)
+		}
+		got, err := test.list(c)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if (len(got) != len(names)) || (got[0] != names[0]) || (got[len(got)-1] != names[len(names)-1]) {
+			t.Errorf("%s: expected %d names, got %d", test.name, len(names), len(got))
+		}
+		if *requests != 3 {
+			t.Errorf("%s: expected 3 requests, got %d", test.name, *requests)
+		}
+	}
+}
+
+func TestListByPageTokenFollowsToken(t *testing.T) {
+	pages := map[string]KVMEntries{
+		"": {
+			KeyValueEntries: []Attribute{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+			NextPageToken:   "page2",
+		},
+		"page2": {
+			KeyValueEntries: []Attribute{{Name: "c", Value: "3"}},
+			NextPageToken:   "page3",
+		},
+		"page3": {
+			KeyValueEntries: []Attribute{{Name: "d", Value: "4"}},
+		},
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("pageSize") != strconv.Itoa(GooglePageSize) {
+			t.Errorf("expected pageSize %d, got %q", GooglePageSize, r.URL.Query().Get("pageSize"))
+		}
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 0)
+	got, err := c.ListEnvironmentKVMEntries("dev", "map")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := ""
+	for _, entry := range got {
+		names += entry.Name
+	}
+	if (names != "abcd") || (requests != 3) {
+		t.Errorf("expected entries abcd from 3 requests, got %s from %d requests", names, requests)
+	}
+}
+
+func TestListByPageTokenStopsOnRepeatedToken(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(KVMEntries{NextPageToken: "same"})
+	}))
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 0)
+	err := c.ListByPageToken("list", nil, func(body io.Reader) (string, error) {
+		page := &KVMEntries{}
+		err := json.NewDecoder(body).Decode(page)
+		return page.NextPageToken, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected to stop once the token repeats, got %d requests", requests)
+	}
+}
@@ -1,5 +1,12 @@
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
 const (
 	ProductPath        = "organizations/%s/apiproducts"
 	ProductPathGet     = ProductPath + "/%s"
@@ -23,6 +30,10 @@ type Product struct {
 	Scopes         []string       `json:"scopes,omitempty"`
 }
 
+type GoogleProductList struct {
+	Products []Product `json:"apiProduct"`
+}
+
 type OperationGroup struct {
 	OperationConfigs    []OperationConfigs `json:"operationConfigs"`
 	OperationConfigType string             `json:"operationConfigType"`
@@ -45,3 +56,43 @@ type OperationConfigs struct {
 	Quota      Quota       `json:"quota,omitempty"`
 	Attributes []Attribute `json:"attributes,omitempty"`
 }
+
+func (c *Client) GetProduct(name string) (*Product, error) {
+	retVal := &Product{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProductPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateProduct(in *Product) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(ProductPath, c.Organization), nil, in, nil)
+}
+
+func (c *Client) UpdateProduct(name string, in *Product) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(ProductPathGet, c.Organization, name), nil, in, nil)
+}
+
+func (c *Client) DeleteProduct(name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProductPathGet, c.Organization, name), nil, nil, nil)
+}
+
+func (c *Client) ListProducts() ([]string, error) {
+	requestPath := fmt.Sprintf(ProductPath, c.Organization)
+	if !c.IsGoogle() {
+		return c.ListByStartKey(requestPath, nil, DecodeNamePage)
+	}
+	return c.ListByStartKey(requestPath, nil, func(body io.Reader) ([]string, error) {
+		page := &GoogleProductList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return nil, err
+		}
+		retVal := []string{}
+		for _, product := range page.Products {
+			retVal = append(retVal, product.Name)
+		}
+		return retVal, nil
+	})
+}
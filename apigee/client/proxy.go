@@ -1,8 +1,21 @@
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-http-utils/headers"
+)
+
 const (
 	ProxyPath           = "organizations/%s/apis"
 	ProxyPathGet        = ProxyPath + "/%s"
+	ProxyRevisionPath   = ProxyPathGet + "/revisions/%d"
 	ProxyDeploymentPath = "organizations/%s/apis/%s/deployments"
 )
 
@@ -11,15 +24,181 @@ type ProxyRevision struct {
 	Revision string `json:"revision"`
 }
 
+type GoogleProxyList struct {
+	Proxies []Proxy `json:"proxies"`
+}
+
 type Proxy struct {
 	Name      string   `json:"name"`
 	Revisions []string `json:"revision"`
 }
 
+type ProxyRevisionDetail struct {
+	Name            string      `json:"name"`
+	Revision        string      `json:"revision"`
+	Description     string      `json:"description"`
+	BasePaths       []string    `json:"basepaths"`
+	ProxyEndpoints  []string    `json:"proxyEndpoints"`
+	TargetEndpoints []string    `json:"targetEndpoints"`
+	Policies        []string    `json:"policies"`
+	Resources       []string    `json:"resources"`
+	CreatedAt       json.Number `json:"createdAt"`
+	LastModifiedAt  json.Number `json:"lastModifiedAt"`
+}
+
 type ProxyDeployments struct {
 	Environments []ProxyDeployment `json:"environment"`
 	ProxyName    string            `json:"name"`
 }
 type ProxyDeployment struct {
-	EnvironmentName string `json:"name"`
+	EnvironmentName string                               `json:"name"`
+	Revisions       []ProxyEnvironmentRevisionDeployment `json:"revision"`
+}
+
+func (c *Client) ListProxies() ([]string, error) {
+	requestPath := fmt.Sprintf(ProxyPath, c.Organization)
+	if !c.IsGoogle() {
+		retVal := []string{}
+		err := c.jsonRequest(http.MethodGet, requestPath, nil, nil, &retVal)
+		if err != nil {
+			return nil, err
+		}
+		return retVal, nil
+	}
+	list := &GoogleProxyList{}
+	err := c.jsonRequest(http.MethodGet, requestPath, nil, nil, list)
+	if err != nil {
+		return nil, err
+	}
+	retVal := []string{}
+	for _, item := range list.Proxies {
+		retVal = append(retVal, item.Name)
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetProxy(name string) (*Proxy, error) {
+	retVal := &Proxy{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ImportProxy(name string, bundle FormData) (*ProxyRevision, error) {
+	//Turn bundle into multi part buffer
+	mp, buf, err := GetMultiPartBuffer(map[string]FormData{
+		"bundle": bundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(ProxyPath, c.Organization)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{mp.FormDataContentType()},
+	}
+	requestQuery := url.Values{
+		"action": []string{"import"},
+		"name":   []string{name},
+	}
+	body, err := c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	retVal := &ProxyRevision{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeleteProxy(name string) error {
+	err := c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyPathGet, c.Organization, name), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	//Revision numbers start over if the proxy is created again
+	c.basePathsMutex.Lock()
+	defer c.basePathsMutex.Unlock()
+	for key := range c.basePaths {
+		if strings.HasPrefix(key, name+IdSeparator) {
+			delete(c.basePaths, key)
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetProxyRevision(name string, revision int) (*ProxyRevisionDetail, error) {
+	retVal := &ProxyRevisionDetail{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyRevisionPath, c.Organization, name, revision), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetProxyRevisionBasePaths(name string, revision int) ([]string, error) {
+	//Revisions are never changed once imported, so remember their base paths for the rest of the run
+	key := name + IdSeparator + strconv.Itoa(revision)
+	c.basePathsMutex.Lock()
+	basePaths, found := c.basePaths[key]
+	c.basePathsMutex.Unlock()
+	if found {
+		return basePaths, nil
+	}
+	detail, err := c.GetProxyRevision(name, revision)
+	if err != nil {
+		return nil, err
+	}
+	c.basePathsMutex.Lock()
+	defer c.basePathsMutex.Unlock()
+	if c.basePaths == nil {
+		c.basePaths = map[string][]string{}
+	}
+	c.basePaths[key] = detail.BasePaths
+	return detail.BasePaths, nil
+}
+
+func (c *Client) ExportProxyRevision(name string, revision int) ([]byte, error) {
+	requestQuery := url.Values{
+		"format": []string{"bundle"},
+	}
+	body, err := c.HttpRequest(http.MethodGet, fmt.Sprintf(ProxyRevisionPath, c.Organization, name, revision), requestQuery, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+func (c *Client) DeleteProxyRevision(name string, revision int) error {
+	err := c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyRevisionPath, c.Organization, name, revision), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	c.basePathsMutex.Lock()
+	defer c.basePathsMutex.Unlock()
+	delete(c.basePaths, name+IdSeparator+strconv.Itoa(revision))
+	return nil
+}
+
+func (c *Client) GetProxyDeployments(name string) (*ProxyDeployments, error) {
+	retVal := &ProxyDeployments{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyDeploymentPath, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleProxyDeployments(name string) (*GoogleProxyEnvironmentDeployment, error) {
+	retVal := &GoogleProxyEnvironmentDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyDeploymentPath, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
 }
@@ -1,10 +1,18 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 const (
+	GoogleServiceAccountPrefix             = "projects/-/serviceAccounts/"
 	ProxyEnvironmentDeploymentPath         = "organizations/%s/environments/%s/apis/%s/deployments"
 	ProxyEnvironmentDeploymentRevisionPath = "organizations/%s/environments/%s/apis/%s/revisions/%d/deployments"
+	ProxyDeployChangeReportPath            = ProxyEnvironmentDeploymentRevisionPath + ":generateDeployChangeReport"
 )
 
 type ProxyEnvironmentDeployment struct {
@@ -24,6 +32,22 @@ type GoogleProxyEnvironmentDeploymentDeployments struct {
 	EnvironmentName string `json:"environment"`
 	Revision        string `json:"revision"`
 	ServiceAccount  string `json:"serviceAccount"`
+	State           string `json:"state"`
+}
+
+type GoogleDeployChangeReport struct {
+	RoutingConflicts []GoogleRoutingConflict `json:"routingConflicts"`
+}
+type GoogleRoutingConflict struct {
+	Description           string                      `json:"description"`
+	EnvironmentGroup      string                      `json:"environmentGroup"`
+	ConflictingDeployment GoogleConflictingDeployment `json:"conflictingDeployment"`
+}
+type GoogleConflictingDeployment struct {
+	ProxyName   string `json:"apiProxy"`
+	BasePath    string `json:"basepath"`
+	Environment string `json:"environment"`
+	Revision    string `json:"revision"`
 }
 
 func (c *ProxyEnvironmentDeployment) ProxyDeploymentEncodeId() string {
@@ -34,3 +58,43 @@ func ProxyDeploymentDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetProxyEnvironmentDeployment(envName string, proxyName string) (*ProxyEnvironmentDeployment, error) {
+	retVal := &ProxyEnvironmentDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyEnvironmentDeploymentPath, c.Organization, envName, proxyName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleProxyEnvironmentDeployment(envName string, proxyName string) (*GoogleProxyEnvironmentDeployment, error) {
+	retVal := &GoogleProxyEnvironmentDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyEnvironmentDeploymentPath, c.Organization, envName, proxyName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeployProxyRevision(envName string, proxyName string, revision int, form url.Values) error {
+	//Deploying the same revision again is harmless
+	return c.formRequest(http.MethodPost, fmt.Sprintf(ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, revision), form, true)
+}
+
+func (c *Client) UndeployProxyRevision(envName string, proxyName string, revision int) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, revision), nil, nil, nil)
+}
+
+func (c *Client) GenerateProxyDeployChangeReport(envName string, proxyName string, revision int, override bool) (*GoogleDeployChangeReport, error) {
+	requestQuery := url.Values{
+		"override": []string{strconv.FormatBool(override)},
+	}
+	retVal := &GoogleDeployChangeReport{}
+	//Generating a report does not change anything
+	err := c.idempotentJsonRequest(http.MethodPost, fmt.Sprintf(ProxyDeployChangeReportPath, c.Organization, envName, proxyName, revision), requestQuery, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
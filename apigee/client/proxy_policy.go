@@ -1,6 +1,8 @@
 package client
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 )
@@ -25,3 +27,20 @@ func ProxyPolicyDecodeId(s string) (string, int, string) {
 	revision, _ := strconv.Atoi(tokens[1])
 	return tokens[0], revision, tokens[2]
 }
+
+func (c *Client) ListProxyPolicies(proxyName string, rev int) ([]string, error) {
+	retVal := []string{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyPolicyPath, c.Organization, proxyName, rev), nil, nil, &retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateProxyPolicy(in *ProxyPolicy, file string) error {
+	return c.xmlFileRequest(http.MethodPost, fmt.Sprintf(ProxyPolicyPath, c.Organization, in.ProxyName, in.Revision), file)
+}
+
+func (c *Client) DeleteProxyPolicy(proxyName string, rev int, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyPolicyPathGet, c.Organization, proxyName, rev, name), nil, nil, nil)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/go-http-utils/headers"
+)
+
+const (
+	Redacted = "REDACTED"
+)
+
+var sensitiveHeaders = []string{
+	headers.Authorization,
+	headers.ProxyAuthorization,
+	headers.Cookie,
+}
+
+var sensitiveFields = map[string]bool{
+	"password":       true,
+	"passcode":       true,
+	"mfa_token":      true,
+	"access_token":   true,
+	"refresh_token":  true,
+	"client_secret":  true,
+	"consumersecret": true,
+	"assertion":      true,
+	"private_key":    true,
+}
+
+func logRequest(req *http.Request, body []byte) {
+	//Never log credentials, only a copy of the request with sensitive headers replaced
+	redactedReq := req.Clone(req.Context())
+	for _, h := range sensitiveHeaders {
+		if redactedReq.Header.Get(h) != "" {
+			redactedReq.Header.Set(h, Redacted)
+		}
+	}
+	//Credentials such as mfa_token can also be sent in the query string
+	redactedReq.URL.RawQuery = redactQuery(req.URL.RawQuery)
+	requestDump, err := httputil.DumpRequest(redactedReq, false)
+	if err != nil {
+		log.Print("Apigee Management API:")
+		log.Print(err)
+		return
+	}
+	log.Print("Apigee Management API: " + string(requestDump) + redactBody(req.URL.Path, req.Header.Get(headers.ContentType), body))
+}
+
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	redacted := false
+	for key := range query {
+		if isSensitiveField(key, false) {
+			query.Set(key, Redacted)
+			redacted = true
+		}
+	}
+	//Keep the original encoding and order unless something had to be hidden
+	if !redacted {
+		return rawQuery
+	}
+	return query.Encode()
+}
+
+func redactBody(path string, contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	//Bundles and uploaded files are binary and can contain keys so only report the size
+	if strings.HasPrefix(contentType, "multipart/") {
+		return fmt.Sprintf("<multipart body: %d bytes>", len(body))
+	}
+	//All KVM values are treated as sensitive since there is no way to know whether the map is encrypted
+	redactValues := strings.Contains(path, "/keyvaluemaps")
+	if strings.HasPrefix(contentType, FormEncoded) {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("<form body: %d bytes>", len(body))
+		}
+		for key := range form {
+			if isSensitiveField(key, redactValues) {
+				form.Set(key, Redacted)
+			}
+		}
+		return form.Encode()
+	}
+	trimmed := bytes.TrimSpace(body)
+	if strings.HasPrefix(contentType, ApplicationJson) || bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		var parsed interface{}
+		err := json.Unmarshal(trimmed, &parsed)
+		if err == nil {
+			redacted, err := json.Marshal(redactJson(parsed, redactValues))
+			if err == nil {
+				return string(redacted)
+			}
+		}
+	}
+	if redactValues {
+		return fmt.Sprintf("<body: %d bytes>", len(body))
+	}
+	return string(body)
+}
+
+func redactJson(v interface{}, redactValues bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if isSensitiveField(key, redactValues) {
+				t[key] = Redacted
+			} else {
+				t[key] = redactJson(value, redactValues)
+			}
+		}
+		return t
+	case []interface{}:
+		for i, value := range t {
+			t[i] = redactJson(value, redactValues)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func isSensitiveField(key string, redactValues bool) bool {
+	lowerKey := strings.ToLower(key)
+	if redactValues && (lowerKey == "value") {
+		return true
+	}
+	return sensitiveFields[lowerKey]
+}
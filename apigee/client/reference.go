@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	ReferencePath    = "organizations/%s/environments/%s/references"
@@ -22,3 +26,25 @@ func ReferenceDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetReference(envName string, name string) (*Reference, error) {
+	retVal := &Reference{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ReferencePathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateReference(in *Reference) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(ReferencePath, c.Organization, in.EnvironmentName), nil, in, nil)
+}
+
+func (c *Client) UpdateReference(in *Reference) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(ReferencePathGet, c.Organization, in.EnvironmentName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteReference(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ReferencePathGet, c.Organization, envName, name), nil, nil, nil)
+}
@@ -13,3 +13,8 @@ type RequestError struct {
 func (r *RequestError) Error() string {
 	return fmt.Sprintf("Status %d: Message: %s: %v", r.StatusCode, http.StatusText(r.StatusCode), r.Err)
 }
+
+func IsNotFound(err error) bool {
+	re, ok := err.(*RequestError)
+	return ok && (re.StatusCode == http.StatusNotFound)
+}
@@ -1,6 +1,9 @@
 package client
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -15,6 +18,9 @@ const (
 	ProxyResourceFilePath              = "organizations/%s/apis/%s/revisions/%d/resourcefiles"
 	ProxyResourceFilePathOfType        = ProxyResourceFilePath + "/%s"
 	ProxyResourceFilePathGet           = ProxyResourceFilePathOfType + "/%s"
+	SharedFlowResourceFilePath         = "organizations/%s/sharedflows/%s/revisions/%d/resourcefiles"
+	SharedFlowResourceFilePathOfType   = SharedFlowResourceFilePath + "/%s"
+	SharedFlowResourceFilePathGet      = SharedFlowResourceFilePathOfType + "/%s"
 )
 
 type ResourceFile struct {
@@ -24,7 +30,9 @@ type ResourceFile struct {
 	EnvironmentName string `json:"-"`
 	//Only used for Proxy context
 	ProxyName string `json:"-"`
-	//Only used for Proxy context
+	//Only used for SharedFlow context
+	SharedFlowName string `json:"-"`
+	//Only used for Proxy and SharedFlow context
 	Revision int `json:"-"`
 }
 type ResourceFilesOfType struct {
@@ -43,6 +51,10 @@ func (c *ResourceFile) ProxyResourceFileEncodeId() string {
 	return c.ProxyName + IdSeparator + strconv.Itoa(c.Revision) + IdSeparator + c.Type + IdSeparator + c.Name
 }
 
+func (c *ResourceFile) SharedFlowResourceFileEncodeId() string {
+	return c.SharedFlowName + IdSeparator + strconv.Itoa(c.Revision) + IdSeparator + c.Type + IdSeparator + c.Name
+}
+
 func OrganizationResourceFileDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
@@ -58,3 +70,133 @@ func ProxyResourceFileDecodeId(s string) (string, int, string, string) {
 	revision, _ := strconv.Atoi(tokens[1])
 	return tokens[0], revision, tokens[2], tokens[3]
 }
+
+func SharedFlowResourceFileDecodeId(s string) (string, int, string, string) {
+	tokens := strings.Split(s, IdSeparator)
+	revision, _ := strconv.Atoi(tokens[1])
+	return tokens[0], revision, tokens[2], tokens[3]
+}
+
+func (c *Client) ListOrganizationResourceFilesOfType(rtype string) ([]ResourceFile, error) {
+	retVal := &ResourceFilesOfType{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(OrganizationResourceFilePathOfType, c.Organization, rtype), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal.Files, nil
+}
+
+func (c *Client) CreateOrganizationResourceFile(in *ResourceFile, file string) error {
+	requestQuery := url.Values{
+		"type": []string{in.Type},
+		"name": []string{in.Name},
+	}
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPost, fmt.Sprintf(OrganizationResourceFilePath, c.Organization), requestQuery, formData, nil)
+}
+
+func (c *Client) UpdateOrganizationResourceFile(in *ResourceFile, file string) error {
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPut, fmt.Sprintf(OrganizationResourceFilePathGet, c.Organization, in.Type, in.Name), nil, formData, nil)
+}
+
+func (c *Client) DeleteOrganizationResourceFile(rtype string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(OrganizationResourceFilePathGet, c.Organization, rtype, name), nil, nil, nil)
+}
+
+func (c *Client) ListEnvironmentResourceFilesOfType(envName string, rtype string) ([]ResourceFile, error) {
+	retVal := &ResourceFilesOfType{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(EnvironmentResourceFilePathOfType, c.Organization, envName, rtype), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal.Files, nil
+}
+
+func (c *Client) CreateEnvironmentResourceFile(in *ResourceFile, file string) error {
+	requestQuery := url.Values{
+		"type": []string{in.Type},
+		"name": []string{in.Name},
+	}
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPost, fmt.Sprintf(EnvironmentResourceFilePath, c.Organization, in.EnvironmentName), requestQuery, formData, nil)
+}
+
+func (c *Client) UpdateEnvironmentResourceFile(in *ResourceFile, file string) error {
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPut, fmt.Sprintf(EnvironmentResourceFilePathGet, c.Organization, in.EnvironmentName, in.Type, in.Name), nil, formData, nil)
+}
+
+func (c *Client) DeleteEnvironmentResourceFile(envName string, rtype string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(EnvironmentResourceFilePathGet, c.Organization, envName, rtype, name), nil, nil, nil)
+}
+
+func (c *Client) ListProxyResourceFilesOfType(proxyName string, rev int, rtype string) ([]ResourceFile, error) {
+	retVal := &ResourceFilesOfType{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(ProxyResourceFilePathOfType, c.Organization, proxyName, rev, rtype), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal.Files, nil
+}
+
+func (c *Client) CreateProxyResourceFile(in *ResourceFile, file string) error {
+	requestQuery := url.Values{
+		"type": []string{in.Type},
+		"name": []string{in.Name},
+	}
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPost, fmt.Sprintf(ProxyResourceFilePath, c.Organization, in.ProxyName, in.Revision), requestQuery, formData, nil)
+}
+
+func (c *Client) UpdateProxyResourceFile(in *ResourceFile, file string) error {
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPut, fmt.Sprintf(ProxyResourceFilePathGet, c.Organization, in.ProxyName, in.Revision, in.Type, in.Name), nil, formData, nil)
+}
+
+func (c *Client) DeleteProxyResourceFile(proxyName string, rev int, rtype string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(ProxyResourceFilePathGet, c.Organization, proxyName, rev, rtype, name), nil, nil, nil)
+}
+
+func (c *Client) ListSharedFlowResourceFilesOfType(sharedFlowName string, rev int, rtype string) ([]ResourceFile, error) {
+	retVal := &ResourceFilesOfType{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowResourceFilePathOfType, c.Organization, sharedFlowName, rev, rtype), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal.Files, nil
+}
+
+func (c *Client) CreateSharedFlowResourceFile(in *ResourceFile, file string) error {
+	requestQuery := url.Values{
+		"type": []string{in.Type},
+		"name": []string{in.Name},
+	}
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPost, fmt.Sprintf(SharedFlowResourceFilePath, c.Organization, in.SharedFlowName, in.Revision), requestQuery, formData, nil)
+}
+
+func (c *Client) UpdateSharedFlowResourceFile(in *ResourceFile, file string) error {
+	formData := map[string]FormData{
+		"file": FormData{Filename: file},
+	}
+	return c.multipartRequest(http.MethodPut, fmt.Sprintf(SharedFlowResourceFilePathGet, c.Organization, in.SharedFlowName, in.Revision, in.Type, in.Name), nil, formData, nil)
+}
+
+func (c *Client) DeleteSharedFlowResourceFile(sharedFlowName string, rev int, rtype string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(SharedFlowResourceFilePathGet, c.Organization, sharedFlowName, rev, rtype, name), nil, nil, nil)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-http-utils/headers"
+)
+
+const (
+	DefaultMaxRetries  = 3
+	DefaultBaseBackoff = 1
+	DefaultMaxBackoff  = 30
+)
+
+var retryableStatusCodes = map[int]bool{
+	//Rate limiting and short outages are worth retrying
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return retryableStatusCodes[statusCode]
+}
+
+func isIdempotentMethod(method string) bool {
+	return (method != http.MethodPost) && (method != http.MethodPatch)
+}
+
+func isRetryableResponse(statusCode int, idempotent bool) bool {
+	//A gateway error does not mean the request was not processed, so a POST could create a duplicate revision or credential
+	//A rate limited request was rejected outright, so it is always safe to send again
+	if !isRetryableStatus(statusCode) {
+		return false
+	}
+	return idempotent || (statusCode == http.StatusTooManyRequests)
+}
+
+func isConnectError(err error) bool {
+	//Failing to connect means nothing was sent, unlike a connection reset part way through a request
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial")
+}
+
+func (c *Client) retryBackoff(attempt int, resp *http.Response) time.Duration {
+	//A valid Retry-After header from the server takes precedence over the exponential backoff
+	//Either way, the wait never exceeds the max backoff
+	maxBackoff := time.Duration(c.maxBackoff) * time.Second
+	if resp != nil {
+		retryAfter, ok := parseRetryAfter(resp.Header.Get(headers.RetryAfter))
+		if ok {
+			if retryAfter > maxBackoff {
+				return maxBackoff
+			}
+			return retryAfter
+		}
+	}
+	backoff := time.Duration(c.baseBackoff) * time.Second
+	for i := 0; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	//Add up to 25% jitter so parallel resources do not retry in lock step
+	if backoff > 0 {
+		backoff += time.Duration(rand.Int63n(int64(backoff)/4 + 1))
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	//Retry-After can either be a number of seconds or an HTTP date
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	retryTime, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := time.Until(retryTime)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingTransport struct {
+	calls int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestClient(t *testing.T, serverURL string, maxRetries int, maxBackoff int) *Client {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(u.Port())
+	//A base backoff of 0 keeps the tests fast while still exercising every retry
+	c, err := NewClient("user", "pass", "", false, u.Hostname(), ServerPath, port, "", "", 0, "org", maxRetries, 0, maxBackoff, "", "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func failingServer(failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	calls := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	return server, calls
+}
+
+func TestHttpRequestRetriesRetryableStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		server, calls := failingServer(2, status, nil)
+		c := newTestClient(t, server.URL, 3, 1)
+		body, err := c.HttpRequest(http.MethodPut, "test", nil, nil, bytes.NewBufferString("payload"))
+		if err != nil {
+			t.Fatalf("status %d: unexpected error: %v", status, err)
+		}
+		//The body must be resent in full on every attempt
+		got, _ := ioutil.ReadAll(body)
+		body.Close()
+		if string(got) != "payload" {
+			t.Errorf("status %d: expected body payload, got %q", status, got)
+		}
+		if *calls != 3 {
+			t.Errorf("status %d: expected 3 calls, got %d", status, *calls)
+		}
+		server.Close()
+	}
+}
+
+func TestHttpRequestDoesNotRetryOtherStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError} {
+		server, calls := failingServer(1, status, nil)
+		c := newTestClient(t, server.URL, 3, 1)
+		_, err := c.HttpRequest(http.MethodGet, "test", nil, nil, nil)
+		re, ok := err.(*RequestError)
+		if !ok || (re.StatusCode != status) {
+			t.Errorf("expected status %d error, got %v", status, err)
+		}
+		if *calls != 1 {
+			t.Errorf("status %d: expected 1 call, got %d", status, *calls)
+		}
+		server.Close()
+	}
+}
+
+func TestHttpRequestStopsAfterMaxRetries(t *testing.T) {
+	server, calls := failingServer(100, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 2, 1)
+	_, err := c.HttpRequest(http.MethodGet, "test", nil, nil, nil)
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusServiceUnavailable) {
+		t.Fatalf("expected status 503 error, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected the first attempt plus 2 retries, got %d calls", *calls)
+	}
+}
+
+func TestHttpRequestOnlyRetriesPostWhenSafe(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		idempotent    bool
+		expectedCalls int32
+	}{
+		{"gateway error", http.StatusBadGateway, false, 1},
+		{"gateway timeout", http.StatusGatewayTimeout, false, 1},
+		{"rate limited", http.StatusTooManyRequests, false, 2},
+		{"idempotent gateway error", http.StatusBadGateway, true, 2},
+	}
+	for _, test := range tests {
+		server, calls := failingServer(1, test.status, nil)
+		c := newTestClient(t, server.URL, 3, 1)
+		if test.idempotent {
+			_, _ = c.IdempotentHttpRequest(http.MethodPost, "test", nil, nil, nil)
+		} else {
+			_, _ = c.HttpRequest(http.MethodPost, "test", nil, nil, nil)
+		}
+		if *calls != test.expectedCalls {
+			t.Errorf("%s: expected %d calls, got %d", test.name, test.expectedCalls, *calls)
+		}
+		server.Close()
+	}
+}
+
+func TestHttpRequestRetriesConnectionReset(t *testing.T) {
+	calls := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			//Drop the connection without a response, like a short Edge outage
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	c := newTestClient(t, server.URL, 3, 1)
+	body, err := c.HttpRequest(http.MethodGet, "test", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body.Close()
+	if *calls != 2 {
+		t.Errorf("expected 2 calls, got %d", *calls)
+	}
+	//The same failure on a POST may have happened after the server processed it
+	atomic.StoreInt32(calls, 0)
+	_, err = c.HttpRequest(http.MethodPost, "test", nil, nil, nil)
+	if err == nil {
+		t.Errorf("expected the POST to fail without retrying")
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 call, got %d", *calls)
+	}
+}
+
+func TestHttpRequestRetriesConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverURL := "http://" + listener.Addr().String()
+	listener.Close()
+	c := newTestClient(t, serverURL, 2, 1)
+	transport := &countingTransport{}
+	c.SetTransport(transport)
+	//Nothing was sent so even a POST is retried
+	_, err = c.HttpRequest(http.MethodPost, "test", nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if transport.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", transport.calls)
+	}
+}
+
+func TestHttpRequestHonorsRetryAfter(t *testing.T) {
+	server, calls := failingServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 3, 30)
+	start := time.Now()
+	body, err := c.HttpRequest(http.MethodGet, "test", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait at least 1s, waited %s", elapsed)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("5")
+	if !ok || (wait != 5*time.Second) {
+		t.Errorf("seconds: expected 5s, got %s %v", wait, ok)
+	}
+	wait, ok = parseRetryAfter(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat))
+	if !ok || (wait < 8*time.Second) || (wait > 10*time.Second) {
+		t.Errorf("HTTP date: expected about 10s, got %s %v", wait, ok)
+	}
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if !ok || (wait != 0) {
+		t.Errorf("past HTTP date: expected 0s, got %s %v", wait, ok)
+	}
+	for _, value := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(value); ok {
+			t.Errorf("expected %q to be ignored", value)
+		}
+	}
+}
+
+func TestRetryBackoffCeiling(t *testing.T) {
+	c := &Client{baseBackoff: 1, maxBackoff: 5}
+	first := c.retryBackoff(0, nil)
+	if (first < time.Second) || (first > time.Second+time.Second/4) {
+		t.Errorf("expected the first backoff to be between 1s and 1.25s, got %s", first)
+	}
+	for attempt := 0; attempt < 20; attempt++ {
+		if wait := c.retryBackoff(attempt, nil); wait > 5*time.Second {
+			t.Errorf("attempt %d: backoff %s exceeds the 5s ceiling", attempt, wait)
+		}
+	}
+	if wait := c.retryBackoff(10, nil); wait != 5*time.Second {
+		t.Errorf("expected a late attempt to wait the full 5s, got %s", wait)
+	}
+	//Retry-After is capped by the ceiling too, in both forms
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := c.retryBackoff(0, resp); wait != 5*time.Second {
+		t.Errorf("expected Retry-After seconds to be capped at 5s, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if wait := c.retryBackoff(0, resp); wait != 5*time.Second {
+		t.Errorf("expected Retry-After date to be capped at 5s, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "2")
+	if wait := c.retryBackoff(3, resp); wait != 2*time.Second {
+		t.Errorf("expected Retry-After to replace the exponential backoff, got %s", wait)
+	}
+}
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"fmt"
+	"net/http"
+)
+
 const (
 	RolePath    = "organizations/%s/userroles"
 	RolePathGet = RolePath + "/%s"
@@ -12,3 +17,24 @@ type Role struct {
 type RoleList struct {
 	Roles []Role `json:"role"`
 }
+
+func (c *Client) GetRole(name string) (*Role, error) {
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(RolePathGet, c.Organization, name), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Role{Name: name}, nil
+}
+
+func (c *Client) CreateRole(in *Role) error {
+	roles := RoleList{
+		Roles: []Role{
+			*in,
+		},
+	}
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(RolePath, c.Organization), nil, roles, nil)
+}
+
+func (c *Client) DeleteRole(name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(RolePathGet, c.Organization, name), nil, nil, nil)
+}
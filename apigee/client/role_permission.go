@@ -1,6 +1,9 @@
 package client
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -23,3 +26,27 @@ func RolePermissionDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetRolePermission(roleName string, path string) (*RolePermission, error) {
+	requestQuery := url.Values{
+		"path": []string{path},
+	}
+	retVal := &RolePermission{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(RolePermissionPath, c.Organization, roleName), requestQuery, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.RoleName = roleName
+	return retVal, nil
+}
+
+func (c *Client) CreateRolePermission(in *RolePermission) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(RolePermissionPath, c.Organization, in.RoleName), nil, in, nil)
+}
+
+func (c *Client) DeleteRolePermission(roleName string, path string, permission string) error {
+	requestQuery := url.Values{
+		"path": []string{path},
+	}
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(RolePermissionPathGet, c.Organization, roleName, permission), requestQuery, nil, nil)
+}
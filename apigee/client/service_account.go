@@ -0,0 +1,125 @@
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+)
+
+const (
+	ServiceAccountKeyType    = "service_account"
+	GoogleTokenURI           = "https://oauth2.googleapis.com/token"
+	GoogleCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	JWTBearerGrantType       = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	//Google caps the lifetime of self-signed assertions at 1 hour
+	JWTLifetime = time.Hour
+)
+
+type ServiceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectId    string `json:"project_id"`
+	PrivateKeyId string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+	signer       *rsa.PrivateKey
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyId     string `json:"kid,omitempty"`
+}
+
+type jwtClaims struct {
+	Issuer    string `json:"iss"`
+	Scope     string `json:"scope"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func ParseServiceAccountKey(keyJson string) (*ServiceAccountKey, error) {
+	key := &ServiceAccountKey{}
+	err := json.Unmarshal([]byte(keyJson), key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account key: %v", err)
+	}
+	if key.Type != ServiceAccountKeyType {
+		return nil, fmt.Errorf("invalid service account key: type must be %s, not %s", ServiceAccountKeyType, key.Type)
+	}
+	if key.ClientEmail == "" {
+		return nil, fmt.Errorf("invalid service account key: missing client_email")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = GoogleTokenURI
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid service account key: private_key is not PEM encoded")
+	}
+	//Google issues PKCS8 keys, but older tooling may produce PKCS1
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsedKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service account key: %v", err)
+		}
+	}
+	signer, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid service account key: private_key is not an RSA key")
+	}
+	key.signer = signer
+	return key, nil
+}
+
+func (k *ServiceAccountKey) signedAssertion(now time.Time) (string, error) {
+	header, err := json.Marshal(jwtHeader{
+		Algorithm: "RS256",
+		Type:      "JWT",
+		KeyId:     k.PrivateKeyId,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(jwtClaims{
+		Issuer:    k.ClientEmail,
+		Scope:     GoogleCloudPlatformScope,
+		Audience:  k.TokenURI,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(JWTLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hashed := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, k.signer, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (c *Client) serviceAccountGrant() error {
+	log.Print("Apigee Management API: Obtaining access token for service account " + c.serviceAccountKey.ClientEmail + "...")
+	assertion, err := c.serviceAccountKey.signedAssertion(time.Now())
+	if err != nil {
+		return err
+	}
+	requestForm := url.Values{
+		"grant_type": []string{JWTBearerGrantType},
+		"assertion":  []string{assertion},
+	}
+	return c.requestOauthToken(c.serviceAccountKey.TokenURI, requestForm, "")
+}
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-http-utils/headers"
+)
+
+const testClientEmail = "terraform@example.iam.gserviceaccount.com"
+
+type fakeTokenEndpoint struct {
+	publicKey *rsa.PublicKey
+	audience  string
+	mutex     sync.Mutex
+	issued    int
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("grant_type") != JWTBearerGrantType {
+		http.Error(w, "unexpected grant_type "+r.Form.Get("grant_type"), http.StatusBadRequest)
+		return
+	}
+	err = f.verifyAssertion(r.Form.Get("assertion"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	f.mutex.Lock()
+	f.issued++
+	accessToken := "token-" + strconv.Itoa(f.issued)
+	f.mutex.Unlock()
+	json.NewEncoder(w).Encode(OauthToken{
+		AccessToken: accessToken,
+		TokenType:   Bearer,
+		ExpiresIn:   3600,
+	})
+}
+
+func (f *fakeTokenEndpoint) verifyAssertion(assertion string) error {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("assertion is not a JWT")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, hashed[:], signature)
+	if err != nil {
+		return err
+	}
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	claims := jwtClaims{}
+	err = json.Unmarshal(rawClaims, &claims)
+	if err != nil {
+		return err
+	}
+	if claims.Issuer != testClientEmail {
+		return fmt.Errorf("unexpected issuer %s", claims.Issuer)
+	}
+	if claims.Audience != f.audience {
+		return fmt.Errorf("unexpected audience %s", claims.Audience)
+	}
+	if claims.Scope != GoogleCloudPlatformScope {
+		return fmt.Errorf("unexpected scope %s", claims.Scope)
+	}
+	return nil
+}
+
+func (f *fakeTokenEndpoint) issuedCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.issued
+}
+
+func newTestServiceAccountKey(t *testing.T, tokenURI string) (string, *rsa.PublicKey) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := map[string]string{
+		"type":           ServiceAccountKeyType,
+		"project_id":     "example",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   testClientEmail,
+	}
+	if tokenURI != "" {
+		key["token_uri"] = tokenURI
+	}
+	keyJson, err := json.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(keyJson), &privateKey.PublicKey
+}
+
+type serviceAccountFixture struct {
+	client        *Client
+	tokens        *fakeTokenEndpoint
+	mutex         sync.Mutex
+	authorization []string
+	rejectNext    bool
+}
+
+func (f *serviceAccountFixture) lastAuthorization() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if len(f.authorization) == 0 {
+		return ""
+	}
+	return f.authorization[len(f.authorization)-1]
+}
+
+func newServiceAccountFixture(t *testing.T) *serviceAccountFixture {
+	f := &serviceAccountFixture{}
+	tokenServer := httptest.NewServer(nil)
+	t.Cleanup(tokenServer.Close)
+	tokenURI := tokenServer.URL + "/token"
+	keyJson, publicKey := newTestServiceAccountKey(t, tokenURI)
+	f.tokens = &fakeTokenEndpoint{publicKey: publicKey, audience: tokenURI}
+	tokenServer.Config.Handler = f.tokens
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		f.authorization = append(f.authorization, r.Header.Get(headers.Authorization))
+		if f.rejectNext {
+			f.rejectNext = false
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	t.Cleanup(apiServer.Close)
+	u, _ := url.Parse(apiServer.URL)
+	port, _ := strconv.Atoi(u.Port())
+	c, err := NewClient("", "", "", false, u.Hostname(), ServerPath, port, "", "", 0, "org", 0, 0, 0, keyJson, "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.client = c
+	return f
+}
+
+func TestServiceAccountMintsTokenFromKeyContent(t *testing.T) {
+	f := newServiceAccountFixture(t)
+	if f.tokens.issuedCount() != 1 {
+		t.Fatalf("expected a token to be minted when the client is created, got %d", f.tokens.issuedCount())
+	}
+	err := f.client.jsonRequest(http.MethodGet, "organizations/org", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastAuthorization(); got != "Bearer token-1" {
+		t.Errorf("expected the minted token to be sent, got %q", got)
+	}
+	if f.tokens.issuedCount() != 1 {
+		t.Errorf("expected the token to be reused, got %d tokens", f.tokens.issuedCount())
+	}
+}
+
+func TestServiceAccountRefreshesExpiredToken(t *testing.T) {
+	f := newServiceAccountFixture(t)
+	f.client.tokenMutex.Lock()
+	f.client.tokenExpiry = time.Now().Add(-time.Second)
+	f.client.tokenMutex.Unlock()
+	err := f.client.jsonRequest(http.MethodGet, "organizations/org", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastAuthorization(); got != "Bearer token-2" {
+		t.Errorf("expected a new token after expiry, got %q", got)
+	}
+	//A token revoked early is replaced once when the server rejects it
+	f.mutex.Lock()
+	f.rejectNext = true
+	f.mutex.Unlock()
+	err = f.client.jsonRequest(http.MethodGet, "organizations/org", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastAuthorization(); got != "Bearer token-3" {
+		t.Errorf("expected a new token after a 401, got %q", got)
+	}
+}
+
+func TestServiceAccountTokenURIOverride(t *testing.T) {
+	keyJson, _ := newTestServiceAccountKey(t, "")
+	key, err := ParseServiceAccountKey(keyJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.TokenURI != GoogleTokenURI {
+		t.Errorf("expected the default token URI %s, got %s", GoogleTokenURI, key.TokenURI)
+	}
+	keyJson, _ = newTestServiceAccountKey(t, "http://127.0.0.1:1/token")
+	key, err = ParseServiceAccountKey(keyJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.TokenURI != "http://127.0.0.1:1/token" {
+		t.Errorf("expected the token URI from the key, got %s", key.TokenURI)
+	}
+}
+
+func TestServiceAccountRejectsInvalidKeys(t *testing.T) {
+	for _, keyJson := range []string{
+		"not json",
+		`{"type": "authorized_user"}`,
+		`{"type": "service_account"}`,
+		`{"type": "service_account", "client_email": "a@b.c", "private_key": "not pem"}`,
+	} {
+		if _, err := ParseServiceAccountKey(keyJson); err == nil {
+			t.Errorf("expected %s to be rejected", keyJson)
+		}
+	}
+}
@@ -1,8 +1,21 @@
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/go-http-utils/headers"
+)
+
 const (
-	SharedFlowPath    = "organizations/%s/sharedflows"
-	SharedFlowPathGet = SharedFlowPath + "/%s"
+	SharedFlowPath            = "organizations/%s/sharedflows"
+	SharedFlowPathGet         = SharedFlowPath + "/%s"
+	SharedFlowRevisionPath    = SharedFlowPathGet + "/revisions/%d"
+	SharedFlowDeploymentsPath = SharedFlowPathGet + "/deployments"
 )
 
 type SharedFlowRevision struct {
@@ -10,7 +23,115 @@ type SharedFlowRevision struct {
 	Revision string `json:"revision"`
 }
 
+type GoogleSharedFlowList struct {
+	SharedFlows []SharedFlow `json:"sharedFlows"`
+}
+
 type SharedFlow struct {
 	Name      string   `json:"name"`
 	Revisions []string `json:"revision"`
 }
+
+type SharedFlowDeployments struct {
+	Environments   []SharedFlowEnvironmentDeployment `json:"environment"`
+	SharedFlowName string                            `json:"name"`
+}
+type SharedFlowEnvironmentDeployment struct {
+	EnvironmentName string                         `json:"name"`
+	Revisions       []SharedFlowRevisionDeployment `json:"revision"`
+}
+
+func (c *Client) ListSharedFlows() ([]string, error) {
+	requestPath := fmt.Sprintf(SharedFlowPath, c.Organization)
+	if !c.IsGoogle() {
+		return c.ListByStartKey(requestPath, nil, DecodeNamePage)
+	}
+	return c.ListByStartKey(requestPath, nil, func(body io.Reader) ([]string, error) {
+		page := &GoogleSharedFlowList{}
+		err := json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return nil, err
+		}
+		retVal := []string{}
+		for _, sharedFlow := range page.SharedFlows {
+			retVal = append(retVal, sharedFlow.Name)
+		}
+		return retVal, nil
+	})
+}
+
+func (c *Client) GetSharedFlow(name string) (*SharedFlow, error) {
+	retVal := &SharedFlow{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowPathGet, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ImportSharedFlow(name string, bundle FormData) (*SharedFlowRevision, error) {
+	//Turn bundle into multi part buffer
+	mp, buf, err := GetMultiPartBuffer(map[string]FormData{
+		"bundle": bundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(SharedFlowPath, c.Organization)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{mp.FormDataContentType()},
+	}
+	requestQuery := url.Values{
+		"action": []string{"import"},
+		"name":   []string{name},
+	}
+	body, err := c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	retVal := &SharedFlowRevision{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) ExportSharedFlowRevision(name string, revision int) ([]byte, error) {
+	requestQuery := url.Values{
+		"format": []string{"bundle"},
+	}
+	body, err := c.HttpRequest(http.MethodGet, fmt.Sprintf(SharedFlowRevisionPath, c.Organization, name, revision), requestQuery, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+func (c *Client) DeleteSharedFlow(name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(SharedFlowPathGet, c.Organization, name), nil, nil, nil)
+}
+
+func (c *Client) DeleteSharedFlowRevision(name string, revision int) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(SharedFlowRevisionPath, c.Organization, name, revision), nil, nil, nil)
+}
+
+func (c *Client) GetSharedFlowDeployments(name string) (*SharedFlowDeployments, error) {
+	retVal := &SharedFlowDeployments{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowDeploymentsPath, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleSharedFlowDeployments(name string) (*GoogleSharedFlowDeployment, error) {
+	retVal := &GoogleSharedFlowDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowDeploymentsPath, c.Organization, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
@@ -1,6 +1,11 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 const (
 	SharedFlowDeploymentPath         = "organizations/%s/environments/%s/sharedflows/%s/deployments"
@@ -36,3 +41,30 @@ func SharedFlowDeploymentDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetSharedFlowEnvironmentDeployment(envName string, sharedFlowName string) (*SharedFlowDeployment, error) {
+	retVal := &SharedFlowDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowDeploymentPath, c.Organization, envName, sharedFlowName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleSharedFlowEnvironmentDeployment(envName string, sharedFlowName string) (*GoogleSharedFlowDeployment, error) {
+	retVal := &GoogleSharedFlowDeployment{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowDeploymentPath, c.Organization, envName, sharedFlowName), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) DeploySharedFlowRevision(envName string, sharedFlowName string, revision int, form url.Values) error {
+	//Deploying the same revision again is harmless
+	return c.formRequest(http.MethodPost, fmt.Sprintf(SharedFlowDeploymentRevisionPath, c.Organization, envName, sharedFlowName, revision), form, true)
+}
+
+func (c *Client) UndeploySharedFlowRevision(envName string, sharedFlowName string, revision int) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(SharedFlowDeploymentRevisionPath, c.Organization, envName, sharedFlowName, revision), nil, nil, nil)
+}
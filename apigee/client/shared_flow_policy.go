@@ -0,0 +1,46 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	SharedFlowPolicyPath    = "organizations/%s/sharedflows/%s/revisions/%d/policies"
+	SharedFlowPolicyPathGet = SharedFlowPolicyPath + "/%s"
+)
+
+type SharedFlowPolicy struct {
+	SharedFlowName string
+	Revision       int
+	Name           string
+}
+
+func (c *SharedFlowPolicy) SharedFlowPolicyEncodeId() string {
+	return c.SharedFlowName + IdSeparator + strconv.Itoa(c.Revision) + IdSeparator + c.Name
+}
+
+func SharedFlowPolicyDecodeId(s string) (string, int, string) {
+	tokens := strings.Split(s, IdSeparator)
+	revision, _ := strconv.Atoi(tokens[1])
+	return tokens[0], revision, tokens[2]
+}
+
+func (c *Client) ListSharedFlowPolicies(sharedFlowName string, rev int) ([]string, error) {
+	retVal := []string{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(SharedFlowPolicyPath, c.Organization, sharedFlowName, rev), nil, nil, &retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateSharedFlowPolicy(in *SharedFlowPolicy, file string) error {
+	return c.xmlFileRequest(http.MethodPost, fmt.Sprintf(SharedFlowPolicyPath, c.Organization, in.SharedFlowName, in.Revision), file)
+}
+
+func (c *Client) DeleteSharedFlowPolicy(sharedFlowName string, rev int, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(SharedFlowPolicyPathGet, c.Organization, sharedFlowName, rev, name), nil, nil, nil)
+}
@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	TargetServerPath    = "organizations/%s/environments/%s/targetservers"
@@ -35,3 +39,43 @@ func TargetServerDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetTargetServer(envName string, name string) (*TargetServer, error) {
+	retVal := &TargetServer{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(TargetServerPathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) GetGoogleTargetServer(envName string, name string) (*GoogleTargetServer, error) {
+	retVal := &GoogleTargetServer{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(TargetServerPathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateTargetServer(ts *TargetServer) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(TargetServerPath, c.Organization, ts.EnvironmentName), nil, ts, nil)
+}
+
+func (c *Client) CreateGoogleTargetServer(ts *GoogleTargetServer) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(TargetServerPath, c.Organization, ts.EnvironmentName), nil, ts, nil)
+}
+
+func (c *Client) UpdateTargetServer(ts *TargetServer) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(TargetServerPathGet, c.Organization, ts.EnvironmentName, ts.Name), nil, ts, nil)
+}
+
+func (c *Client) UpdateGoogleTargetServer(ts *GoogleTargetServer) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(TargetServerPathGet, c.Organization, ts.EnvironmentName, ts.Name), nil, ts, nil)
+}
+
+func (c *Client) DeleteTargetServer(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(TargetServerPathGet, c.Organization, envName, name), nil, nil, nil)
+}
@@ -0,0 +1,343 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type recordedRequest struct {
+	Method      string
+	Path        string
+	Query       string
+	ContentType string
+	Body        string
+}
+
+type mockResponse struct {
+	Status int
+	Body   string
+}
+
+func mockServer(t *testing.T, responses map[string]mockResponse) (*httptest.Server, *[]recordedRequest) {
+	//Serves canned responses keyed by "METHOD path" and records every request in order
+	requests := &[]recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/"+ServerPath+"/")
+		*requests = append(*requests, recordedRequest{
+			Method:      r.Method,
+			Path:        path,
+			Query:       r.URL.RawQuery,
+			ContentType: r.Header.Get("Content-Type"),
+			Body:        string(body),
+		})
+		resp, ok := responses[r.Method+" "+path]
+		if !ok {
+			resp = mockResponse{Status: http.StatusOK, Body: "{}"}
+		}
+		if resp.Status == 0 {
+			resp.Status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", ApplicationJson)
+		w.WriteHeader(resp.Status)
+		w.Write([]byte(resp.Body))
+	}))
+	return server, requests
+}
+
+func TestGetCacheDecodesResponse(t *testing.T) {
+	server, requests := mockServer(t, map[string]mockResponse{
+		"GET organizations/org/environments/test/caches/c1": {Body: `{"name":"c1","description":"desc","expirySettings":{"timeoutInSec":{"value":"300"}}}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	cache, err := c.GetCache("test", "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (cache.EnvironmentName != "test") || (cache.Name != "c1") || (cache.Description != "desc") {
+		t.Errorf("unexpected cache %+v", cache)
+	}
+	if (cache.ExpirySettings.TimeoutInSec == nil) || (cache.ExpirySettings.TimeoutInSec.Value != "300") {
+		t.Errorf("expected timeout of 300, got %+v", cache.ExpirySettings)
+	}
+	if len(*requests) != 1 {
+		t.Errorf("expected 1 request, got %d", len(*requests))
+	}
+}
+
+func TestGetReturnsNotFound(t *testing.T) {
+	server, _ := mockServer(t, map[string]mockResponse{
+		"GET organizations/org/environments/test/virtualhosts/missing": {Status: http.StatusNotFound, Body: `{"code":"not found"}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	_, err := c.GetVirtualHost("test", "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestCreateDeveloperAppReturnsGeneratedCredentials(t *testing.T) {
+	server, requests := mockServer(t, map[string]mockResponse{
+		"POST organizations/org/developers/dev@example.com/apps": {Status: http.StatusCreated, Body: `{"name":"app","credentials":[{"consumerKey":"generated"}]}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	retVal, err := c.CreateDeveloperApp(&App{
+		DeveloperEmail: "dev@example.com",
+		Name:           "app",
+		CallbackURL:    "https://example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(retVal.Credentials) != 1) || (retVal.Credentials[0].ConsumerKey != "generated") {
+		t.Errorf("expected the generated credential, got %+v", retVal.Credentials)
+	}
+	if retVal.DeveloperEmail != "dev@example.com" {
+		t.Errorf("expected developer email to be kept, got %q", retVal.DeveloperEmail)
+	}
+	sent := map[string]interface{}{}
+	err = json.Unmarshal([]byte((*requests)[0].Body), &sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (sent["name"] != "app") || (sent["callbackUrl"] != "https://example.com") {
+		t.Errorf("unexpected request body %v", sent)
+	}
+	if (*requests)[0].ContentType != ApplicationJson {
+		t.Errorf("expected content type %s, got %s", ApplicationJson, (*requests)[0].ContentType)
+	}
+}
+
+func TestUpdateAppCredentialPostsThenPuts(t *testing.T) {
+	server, requests := mockServer(t, nil)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	err := c.UpdateCompanyAppCredential(&AppCredentialModify{
+		CompanyName: "company",
+		AppName:     "app",
+		ConsumerKey: "key",
+		Scopes:      []string{"read"},
+		APIProducts: []string{"product"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"POST organizations/org/companies/company/apps/app/keys/key",
+		"PUT organizations/org/companies/company/apps/app/keys/key",
+	}
+	if len(*requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(*requests))
+	}
+	for i, e := range expected {
+		got := (*requests)[i].Method + " " + (*requests)[i].Path
+		if got != e {
+			t.Errorf("request %d: expected %s, got %s", i, e, got)
+		}
+	}
+}
+
+func TestCreateUserRoleSendsForm(t *testing.T) {
+	server, requests := mockServer(t, nil)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	err := c.CreateUserRole(&UserRole{
+		EmailId:  "user@example.com",
+		RoleName: "role",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := (*requests)[0]
+	if (r.Method != http.MethodPost) || (r.Path != "organizations/org/userroles/role/users") {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if (r.ContentType != FormEncoded) || (r.Body != "id=user%40example.com") {
+		t.Errorf("unexpected form %s %q", r.ContentType, r.Body)
+	}
+}
+
+func TestGetRolePermissionSendsPath(t *testing.T) {
+	server, requests := mockServer(t, map[string]mockResponse{
+		"GET organizations/org/userroles/role/permissions": {Body: `{"path":"/apis","permissions":["get","put"]}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	retVal, err := c.GetRolePermission("role", "/apis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (retVal.RoleName != "role") || (len(retVal.Permissions) != 2) {
+		t.Errorf("unexpected permission %+v", retVal)
+	}
+	if (*requests)[0].Query != "path=%2Fapis" {
+		t.Errorf("expected path query, got %q", (*requests)[0].Query)
+	}
+}
+
+func TestListCompanyDevelopersSetsCompany(t *testing.T) {
+	server, _ := mockServer(t, map[string]mockResponse{
+		"GET organizations/org/companies/company/developers": {Body: `{"developer":[{"email":"a@example.com","role":"admin"},{"email":"b@example.com"}]}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	retVal, err := c.ListCompanyDevelopers("company")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retVal) != 2 {
+		t.Fatalf("expected 2 developers, got %d", len(retVal))
+	}
+	for _, cd := range retVal {
+		if cd.CompanyName != "company" {
+			t.Errorf("expected company to be set on %+v", cd)
+		}
+	}
+}
+
+func TestUpdateEnvironmentKVMEntryIsRetried(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server.URL, 1, 1)
+	err := c.UpdateEnvironmentKVMEntry("test", "kvm", &Attribute{Name: "k", Value: "v"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the entry change to be sent again, got %d calls", calls)
+	}
+}
+
+func TestCreateResourceFileSendsMultipart(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "script.js")
+	err := ioutil.WriteFile(file, []byte("var a = 1;"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, requests := mockServer(t, nil)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	err = c.CreateProxyResourceFile(&ResourceFile{
+		ProxyName: "proxy",
+		Revision:  2,
+		Type:      "jsc",
+		Name:      "script.js",
+	}, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := (*requests)[0]
+	if r.Path != "organizations/org/apis/proxy/revisions/2/resourcefiles" {
+		t.Errorf("unexpected path %s", r.Path)
+	}
+	if r.Query != "name=script.js&type=jsc" {
+		t.Errorf("unexpected query %q", r.Query)
+	}
+	if !strings.HasPrefix(r.ContentType, "multipart/form-data") || !strings.Contains(r.Body, "var a = 1;") {
+		t.Errorf("expected the file as multipart, got %s %q", r.ContentType, r.Body)
+	}
+}
+
+func TestCreatePolicySendsXml(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.xml")
+	err := ioutil.WriteFile(file, []byte("<AssignMessage name=\"am\"/>"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, requests := mockServer(t, nil)
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	err = c.CreateSharedFlowPolicy(&SharedFlowPolicy{
+		SharedFlowName: "flow",
+		Revision:       1,
+		Name:           "am",
+	}, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := (*requests)[0]
+	if (r.Path != "organizations/org/sharedflows/flow/revisions/1/policies") || (r.ContentType != ApplicationXml) {
+		t.Errorf("unexpected request %s %s", r.Path, r.ContentType)
+	}
+	//A missing file is reported without sending anything
+	err = c.CreateSharedFlowPolicy(&SharedFlowPolicy{SharedFlowName: "flow", Revision: 1}, filepath.Join(os.TempDir(), "missing-policy.xml"))
+	if err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if len(*requests) != 1 {
+		t.Errorf("expected 1 request, got %d", len(*requests))
+	}
+}
+
+func TestProxyRevisionBasePathsAreCached(t *testing.T) {
+	server, requests := mockServer(t, map[string]mockResponse{
+		"GET organizations/org/apis/proxy/revisions/1": {Body: `{"name":"proxy","revision":"1","basepaths":["/v1"]}`},
+	})
+	defer server.Close()
+	c := newTestClient(t, server.URL, 0, 1)
+	for i := 0; i < 2; i++ {
+		basePaths, err := c.GetProxyRevisionBasePaths("proxy", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (len(basePaths) != 1) || (basePaths[0] != "/v1") {
+			t.Errorf("unexpected base paths %q", basePaths)
+		}
+	}
+	if len(*requests) != 1 {
+		t.Errorf("expected 1 request, got %d", len(*requests))
+	}
+	//Deleting the revision forgets its base paths
+	err := c.DeleteProxyRevision("proxy", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.GetProxyRevisionBasePaths("proxy", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*requests) != 3 {
+		t.Errorf("expected the base paths to be fetched again, got %d requests", len(*requests))
+	}
+}
+
+func TestUpdateGoogleEnvironmentReturnsOperation(t *testing.T) {
+	server, _ := mockServer(t, map[string]mockResponse{
+		"PUT organizations/org/environments/slow": {Body: `{"name":"organizations/org/operations/1234","done":false}`},
+		"PUT organizations/org/environments/fast": {Body: `{"name":"fast","displayName":"Fast"}`},
+	})
+	defer server.Close()
+	c := newGoogleTestClient(t, server.URL)
+	op, err := c.UpdateEnvironment("slow", &Environment{Name: "slow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (op == nil) || (op.Name != "organizations/org/operations/1234") {
+		t.Errorf("expected the operation to be returned, got %+v", op)
+	}
+	//An environment in the response means there is nothing to wait for
+	op, err = c.UpdateEnvironment("fast", &Environment{Name: "fast"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op != nil {
+		t.Errorf("expected no operation, got %+v", op)
+	}
+}
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"fmt"
+	"net/http"
+)
+
 const (
 	UserPath    = "users"
 	UserPathGet = UserPath + "/%s"
@@ -11,3 +16,25 @@ type User struct {
 	LastName  string `json:"lastName"`
 	Password  string `json:"password,omitempty"`
 }
+
+func (c *Client) GetUser(emailId string) (*User, error) {
+	retVal := &User{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(UserPathGet, emailId), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+func (c *Client) CreateUser(in *User) error {
+	return c.jsonRequest(http.MethodPost, UserPath, nil, in, nil)
+}
+
+func (c *Client) UpdateUser(emailId string, in *User) error {
+	//The email id of the user can be changed by the update itself
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(UserPathGet, emailId), nil, in, nil)
+}
+
+func (c *Client) DeleteUser(emailId string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(UserPathGet, emailId), nil, nil, nil)
+}
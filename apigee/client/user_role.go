@@ -1,6 +1,11 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 const (
 	UserRolePath    = "organizations/%s/userroles/%s/users"
@@ -20,3 +25,25 @@ func UserRoleDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetUserRole(emailId string, roleName string) (*UserRole, error) {
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(UserRolePathGet, c.Organization, roleName, emailId), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UserRole{
+		EmailId:  emailId,
+		RoleName: roleName,
+	}, nil
+}
+
+func (c *Client) CreateUserRole(in *UserRole) error {
+	requestForm := url.Values{
+		"id": []string{in.EmailId},
+	}
+	return c.formRequest(http.MethodPost, fmt.Sprintf(UserRolePath, c.Organization, in.RoleName), requestForm, false)
+}
+
+func (c *Client) DeleteUserRole(emailId string, roleName string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(UserRolePathGet, c.Organization, roleName, emailId), nil, nil, nil)
+}
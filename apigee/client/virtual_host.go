@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 const (
 	VirtualHostPath    = "organizations/%s/environments/%s/virtualhosts"
@@ -24,3 +28,25 @@ func VirtualHostDecodeId(s string) (string, string) {
 	tokens := strings.Split(s, IdSeparator)
 	return tokens[0], tokens[1]
 }
+
+func (c *Client) GetVirtualHost(envName string, name string) (*VirtualHost, error) {
+	retVal := &VirtualHost{}
+	err := c.jsonRequest(http.MethodGet, fmt.Sprintf(VirtualHostPathGet, c.Organization, envName, name), nil, nil, retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.EnvironmentName = envName
+	return retVal, nil
+}
+
+func (c *Client) CreateVirtualHost(in *VirtualHost) error {
+	return c.jsonRequest(http.MethodPost, fmt.Sprintf(VirtualHostPath, c.Organization, in.EnvironmentName), nil, in, nil)
+}
+
+func (c *Client) UpdateVirtualHost(in *VirtualHost) error {
+	return c.jsonRequest(http.MethodPut, fmt.Sprintf(VirtualHostPathGet, c.Organization, in.EnvironmentName, in.Name), nil, in, nil)
+}
+
+func (c *Client) DeleteVirtualHost(envName string, name string) error {
+	return c.jsonRequest(http.MethodDelete, fmt.Sprintf(VirtualHostPathGet, c.Organization, envName, name), nil, nil, nil)
+}
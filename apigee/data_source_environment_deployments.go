@@ -0,0 +1,108 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"strconv"
+	"strings"
+)
+
+func dataSourceEnvironmentDeployments() *schema.Resource {
+	deploymentSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"service_account": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceEnvironmentDeploymentsRead,
+		Schema: map[string]*schema.Schema{
+			"environment_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"proxies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     deploymentSchema,
+			},
+			"shared_flows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     deploymentSchema,
+			},
+		},
+	}
+}
+
+func readEnvironmentDeployments(c *client.Client, envName string, sharedFlows bool) ([]map[string]interface{}, error) {
+	retVal := []map[string]interface{}{}
+	if c.IsGoogle() {
+		googleDeployments, err := c.GetGoogleEnvironmentDeployments(envName, sharedFlows)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range googleDeployments.Deployments {
+			revision, _ := strconv.Atoi(dep.Revision)
+			retVal = append(retVal, map[string]interface{}{
+				"name":            dep.ProxyName,
+				"revision":        revision,
+				"state":           dep.State,
+				"service_account": strings.TrimPrefix(dep.ServiceAccount, client.GoogleServiceAccountPrefix),
+			})
+		}
+	} else {
+		oldDeployments, err := c.GetEnvironmentDeployments(envName, sharedFlows)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range oldDeployments.Deployments {
+			for _, rev := range dep.Revisions {
+				revision, _ := strconv.Atoi(rev.Name)
+				retVal = append(retVal, map[string]interface{}{
+					"name":            dep.Name,
+					"revision":        revision,
+					"state":           rev.State,
+					"service_account": "",
+				})
+			}
+		}
+	}
+	return retVal, nil
+}
+
+func dataSourceEnvironmentDeploymentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	envName := d.Get("environment_name").(string)
+	proxies, err := readEnvironmentDeployments(c, envName, false)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	sharedFlows, err := readEnvironmentDeployments(c, envName, true)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.Set("proxies", proxies)
+	d.Set("shared_flows", sharedFlows)
+	d.SetId(envName)
+	return diags
+}
@@ -0,0 +1,91 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"sort"
+	"strconv"
+)
+
+func dataSourceInstances() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceInstancesRead,
+		Schema: map[string]*schema.Schema{
+			"instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"peering_cidr_range": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_range": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceInstancesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	if !c.IsGoogle() {
+		return diag.Errorf("apigee_instances is only supported for Google Cloud Apigee versions")
+	}
+	retVal, err := c.ListInstances()
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	sort.Slice(retVal, func(i, j int) bool {
+		return retVal[i].Name < retVal[j].Name
+	})
+	instances := []map[string]interface{}{}
+	for _, instance := range retVal {
+		port, _ := strconv.Atoi(instance.Port)
+		instances = append(instances, map[string]interface{}{
+			"name":               instance.Name,
+			"display_name":       instance.DisplayName,
+			"location":           instance.Location,
+			"host":               instance.Host,
+			"port":               port,
+			"peering_cidr_range": instance.PeeringCidrRange,
+			"ip_range":           instance.IpRange,
+			"state":              instance.State,
+		})
+	}
+	d.Set("instances", instances)
+	d.SetId(c.Organization)
+	return diags
+}
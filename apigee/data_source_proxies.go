@@ -0,0 +1,57 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"regexp"
+	"sort"
+)
+
+func dataSourceProxies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceProxiesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProxiesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	retVal, err := c.ListProxies()
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	nameRegex := d.Get("name_regex").(string)
+	names := []string{}
+	if nameRegex != "" {
+		r := regexp.MustCompile(nameRegex)
+		for _, name := range retVal {
+			if r.MatchString(name) {
+				names = append(names, name)
+			}
+		}
+	} else {
+		names = retVal
+	}
+	sort.Strings(names)
+	d.Set("names", names)
+	d.SetId(c.Organization)
+	return diags
+}
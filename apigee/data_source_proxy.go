@@ -0,0 +1,103 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"strconv"
+)
+
+func dataSourceProxy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceProxyRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"latest_revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"deployments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"environment_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"revision": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	name := d.Get("name").(string)
+	retVal, err := c.GetProxy(name)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	revisions, err := sortRevisions(retVal.Revisions)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	if len(revisions) == 0 {
+		d.SetId("")
+		return diag.Errorf("proxy has no latest revision")
+	}
+	deployments := []map[string]interface{}{}
+	if c.IsGoogle() {
+		googleDeployments, err := c.GetGoogleProxyDeployments(name)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		for _, dep := range googleDeployments.Deployments {
+			revision, _ := strconv.Atoi(dep.Revision)
+			deployments = append(deployments, map[string]interface{}{
+				"environment_name": dep.EnvironmentName,
+				"revision":         revision,
+			})
+		}
+	} else {
+		oldDeployments, err := c.GetProxyDeployments(name)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		for _, env := range oldDeployments.Environments {
+			for _, rev := range env.Revisions {
+				revision, _ := strconv.Atoi(rev.Name)
+				deployments = append(deployments, map[string]interface{}{
+					"environment_name": env.EnvironmentName,
+					"revision":         revision,
+				})
+			}
+		}
+	}
+	d.Set("revisions", revisions)
+	d.Set("latest_revision", revisions[len(revisions)-1])
+	d.Set("deployments", deployments)
+	d.SetId(name)
+	return diags
+}
@@ -0,0 +1,53 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"strconv"
+)
+
+func dataSourceProxyBundle() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceProxyBundleRead,
+		Schema:      bundleDataSourceSchema(),
+	}
+}
+
+func dataSourceProxyBundleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	name := d.Get("name").(string)
+	revision := d.Get("revision").(int)
+	if revision == 0 {
+		retVal, err := c.GetProxy(name)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		revisions, err := sortRevisions(retVal.Revisions)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		if len(revisions) == 0 {
+			d.SetId("")
+			return diag.Errorf("proxy has no latest revision")
+		}
+		revision = revisions[len(revisions)-1]
+	}
+	data, err := c.ExportProxyRevision(name, revision)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	err = writeBundleDataSource(d, data, client.ProxyBundleRoot)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.Set("revision", revision)
+	d.SetId(name + client.IdSeparator + strconv.Itoa(revision))
+	return diags
+}
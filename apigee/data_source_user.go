@@ -1,15 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 )
 
@@ -38,14 +34,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
 	emailId := d.Get("email_id").(string)
-	requestPath := fmt.Sprintf("users/%s", emailId)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	retVal := &client.User{}
-	err = json.NewDecoder(body).Decode(retVal)
+	retVal, err := c.GetUser(emailId)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
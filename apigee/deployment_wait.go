@@ -0,0 +1,62 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDeploymentTimeout = 10 * time.Minute
+	DeploymentPollInterval   = 5 * time.Second
+)
+
+func waitForDeployment(ctx context.Context, timeout time.Duration, getStatus func() (*client.DeploymentStatus, error)) error {
+	//The deploy POST returns before the revision is serving traffic so poll until every instance reports in
+	return pollUntil(ctx, timeout, DeploymentPollInterval, "deployment to become ready", func() (bool, error) {
+		status, err := getStatus()
+		if err != nil {
+			//The deployment can briefly be missing right after the POST
+			if client.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if status.State == client.DeploymentStateError {
+			if len(status.Errors) == 0 {
+				return false, fmt.Errorf("deployment failed without reporting any errors")
+			}
+			return false, fmt.Errorf("deployment failed:\n  %s", strings.Join(status.Errors, "\n  "))
+		}
+		return status.State == client.DeploymentStateReady, nil
+	})
+}
+
+func pollUntil(ctx context.Context, timeout time.Duration, interval time.Duration, what string, check func() (bool, error)) error {
+	//Check right away, then every interval until done, failed or out of time
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timeout after %s waiting for %s", timeout, what)
+		}
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
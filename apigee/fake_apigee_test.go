@@ -0,0 +1,1586 @@
+package apigee
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	fakeServerManagedProperty = "features.isCpsEnabled"
+)
+
+var (
+	fakeDeploymentKey = regexp.MustCompile(`^organizations/([^/]+)/environments/([^/]+)/(apis|sharedflows)/([^/]+)/revisions/([^/]+)/deployments$`)
+	fakeBasePath      = regexp.MustCompile(`<BasePath>\s*([^<\s]+)\s*</BasePath>`)
+	fakeDescription   = regexp.MustCompile(`<Description>([^<]*)</Description>`)
+)
+
+type fakeApigee struct {
+	google   bool
+	server   *httptest.Server
+	target   *url.URL
+	mutex    sync.Mutex
+	docs     map[string]map[string]interface{}
+	bundles  map[string][]byte
+	sequence int
+	routes   []fakeRoute
+}
+
+type fakeRequest struct {
+	*http.Request
+	path  string
+	match []string
+	body  []byte
+}
+
+type fakeRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(req *fakeRequest) (int, interface{})
+}
+
+type fakeDeployment struct {
+	path           string
+	environment    string
+	kind           string
+	name           string
+	revision       string
+	serviceAccount string
+}
+
+func newFakeApigee(t *testing.T, google bool) *fakeApigee {
+	//In-process Apigee management API that keeps every entity as a JSON document keyed by its path
+	f := &fakeApigee{
+		google:  google,
+		docs:    map[string]map[string]interface{}{},
+		bundles: map[string][]byte{},
+	}
+	f.addRoutes()
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	target, err := url.Parse(f.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.target = target
+	f.seed("organizations/"+testAccOrganization, map[string]interface{}{
+		"name": testAccOrganization,
+	})
+	f.seed(fmt.Sprintf(client.EnvironmentPathGet, testAccOrganization, testAccEnvironment), map[string]interface{}{
+		"name": testAccEnvironment,
+	})
+	return f
+}
+
+func (f *fakeApigee) RoundTrip(req *http.Request) (*http.Response, error) {
+	//Keep the configured server name, which decides between Edge and Google, but send every request to the fake
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = f.target.Scheme
+	redirected.URL.Host = f.target.Host
+	redirected.Host = ""
+	return http.DefaultTransport.RoundTrip(redirected)
+}
+
+func (f *fakeApigee) client(t *testing.T) *client.Client {
+	//For tests that call provider functions directly instead of running terraform
+	username, password, accessToken, server := "user", "pass", "", "edge.example.com"
+	if f.google {
+		username, password, accessToken, server = "", "", "token", client.GoogleApigeeServer
+	}
+	c, err := client.NewClient(username, password, accessToken, true, server, client.ServerPath, 443, "", "", 0, testAccOrganization, 0, 0, 1, "", "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetTransport(f)
+	return c
+}
+
+func (f *fakeApigee) seed(path string, doc map[string]interface{}) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.google && (path == fmt.Sprintf(client.EnvironmentPathGet, orgName(path), lastSegment(path))) {
+		f.environmentDefaults(doc)
+	}
+	f.docs[path] = doc
+}
+
+func (f *fakeApigee) exists(path string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	_, found := f.docs[path]
+	return found
+}
+
+func (f *fakeApigee) get(path string) map[string]interface{} {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.docs[path]
+}
+
+func (f *fakeApigee) attachmentPath(collection string, envName string) string {
+	//Attachments get generated names, so find the one for the environment, or return the collection itself
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, name := range f.children(collection) {
+		if f.docs[collection+"/"+name]["environment"] == envName {
+			return collection + "/" + name
+		}
+	}
+	return collection
+}
+
+func (f *fakeApigee) addRevision(kind string, name string, basePaths ...string) int {
+	//Add a revision without a bundle, for resources that only need one to exist
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	parent := fmt.Sprintf("organizations/%s/%s/%s", testAccOrganization, kind, name)
+	if _, found := f.docs[parent]; !found {
+		f.docs[parent] = map[string]interface{}{
+			"name": name,
+		}
+	}
+	revision := f.nextRevision(parent)
+	detail := f.revisionDetail(name, revision, "", nil)
+	detail["basepaths"] = append([]string{}, basePaths...)
+	f.docs[parent+"/revisions/"+strconv.Itoa(revision)] = detail
+	return revision
+}
+
+func (f *fakeApigee) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	status, out := f.handle(r, body)
+	if status >= http.StatusBadRequest {
+		message, _ := out.(string)
+		out = f.errorBody(status, message)
+	}
+	if data, ok := out.([]byte); ok {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(status)
+		w.Write(data)
+		return
+	}
+	w.Header().Set("Content-Type", client.ApplicationJson)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(out)
+}
+
+func (f *fakeApigee) handle(r *http.Request, body []byte) (int, interface{}) {
+	if !f.authorized(r) {
+		return http.StatusUnauthorized, "missing or invalid credentials"
+	}
+	req := &fakeRequest{
+		Request: r,
+		path:    strings.TrimPrefix(r.URL.Path, "/"+client.ServerPath+"/"),
+		body:    body,
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, route := range f.routes {
+		if route.method != r.Method {
+			continue
+		}
+		match := route.pattern.FindStringSubmatch(req.path)
+		if match == nil {
+			continue
+		}
+		req.match = match
+		return route.handle(req)
+	}
+	return f.generic(req)
+}
+
+func (f *fakeApigee) authorized(r *http.Request) bool {
+	if f.google {
+		return strings.HasPrefix(r.Header.Get("Authorization"), client.Bearer+" ")
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && (username != "") && (password != "")
+}
+
+func (f *fakeApigee) errorBody(status int, message string) interface{} {
+	if f.google {
+		googleStatus := map[int]string{
+			http.StatusBadRequest:       "INVALID_ARGUMENT",
+			http.StatusUnauthorized:     "UNAUTHENTICATED",
+			http.StatusNotFound:         "NOT_FOUND",
+			http.StatusMethodNotAllowed: "UNIMPLEMENTED",
+			http.StatusConflict:         "ALREADY_EXISTS",
+		}[status]
+		return map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    status,
+				"message": message,
+				"status":  googleStatus,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"code":     "fake." + strings.ReplaceAll(http.StatusText(status), " ", ""),
+		"message":  message,
+		"contexts": []interface{}{},
+	}
+}
+
+func (f *fakeApigee) route(method string, pattern string, handle func(req *fakeRequest) (int, interface{})) {
+	f.routes = append(f.routes, fakeRoute{
+		method:  method,
+		pattern: regexp.MustCompile(pattern),
+		handle:  handle,
+	})
+}
+
+func (f *fakeApigee) addRoutes() {
+	f.route(http.MethodGet, `^organizations/[^/]+/operations/[^/]+$`, f.getItem)
+	//Users and roles
+	f.route(http.MethodPost, `^organizations/[^/]+/userroles$`, f.createRoles)
+	f.route(http.MethodPost, `^organizations/[^/]+/userroles/[^/]+/users$`, f.addUserRole)
+	f.route(http.MethodGet, `^organizations/[^/]+/userroles/[^/]+/permissions$`, f.getRolePermission)
+	f.route(http.MethodPost, `^organizations/[^/]+/userroles/[^/]+/permissions$`, f.addRolePermission)
+	f.route(http.MethodDelete, `^(organizations/[^/]+/userroles/[^/]+/permissions)/([^/]+)$`, f.removeRolePermission)
+	//Companies, apps and credentials
+	f.route(http.MethodGet, `^organizations/[^/]+/companies/[^/]+/developers$`, f.listCompanyDevelopers)
+	f.route(http.MethodPost, `^(organizations/[^/]+)/companies/[^/]+/developers$`, f.setCompanyDevelopers)
+	f.route(http.MethodPost, `^organizations/[^/]+/(?:developers|companies)/[^/]+/apps$`, f.createApp)
+	f.route(http.MethodPost, `^organizations/[^/]+/(?:developers|companies)/[^/]+/apps/[^/]+/keys/create$`, f.createCredential)
+	f.route(http.MethodPost, `^organizations/[^/]+/(?:developers|companies)/[^/]+/apps/[^/]+/keys/[^/]+$`, f.addCredentialProducts)
+	f.route(http.MethodPut, `^organizations/[^/]+/(?:developers|companies)/[^/]+/apps/[^/]+/keys/[^/]+$`, f.setCredentialScopes)
+	f.route(http.MethodDelete, `^(organizations/[^/]+/(?:developers|companies)/[^/]+/apps/[^/]+/keys/[^/]+)/apiproducts/([^/]+)$`, f.removeCredentialProduct)
+	//Key value map entries
+	f.route(http.MethodGet, `^(.+/keyvaluemaps/[^/]+)/entries$`, f.listKVMEntries)
+	f.route(http.MethodPost, `^(.+/keyvaluemaps/[^/]+)/entries$`, f.createKVMEntry)
+	f.route(http.MethodPost, `^(.+/keyvaluemaps/[^/]+)/entries/([^/]+)$`, f.updateKVMEntry)
+	f.route(http.MethodDelete, `^(.+/keyvaluemaps/[^/]+)/entries/([^/]+)$`, f.deleteKVMEntry)
+	//Resource files and policies
+	f.route(http.MethodPost, `^(.+)/resourcefiles$`, f.createResourceFile)
+	f.route(http.MethodGet, `^(.+)/resourcefiles/[^/]+$`, f.listResourceFiles)
+	f.route(http.MethodPut, `^.+/resourcefiles/[^/]+/[^/]+$`, f.updateResourceFile)
+	f.route(http.MethodDelete, `^.+/resourcefiles/[^/]+/[^/]+$`, f.deleteItem)
+	f.route(http.MethodPost, `^organizations/[^/]+/(?:apis|sharedflows)/[^/]+/revisions/[^/]+/policies$`, f.createPolicy)
+	//Proxies, shared flows and their deployments
+	f.route(http.MethodGet, `^organizations/[^/]+/apis$`, f.listProxies)
+	f.route(http.MethodPost, `^organizations/[^/]+/(apis|sharedflows)$`, f.importBundle)
+	f.route(http.MethodGet, `^organizations/[^/]+/(?:apis|sharedflows)/[^/]+$`, f.getBundle)
+	f.route(http.MethodDelete, `^(organizations/[^/]+)/(apis|sharedflows)/([^/]+)$`, f.deleteBundle)
+	f.route(http.MethodGet, `^organizations/[^/]+/(?:apis|sharedflows)/[^/]+/revisions/[^/]+$`, f.getRevision)
+	f.route(http.MethodDelete, `^(organizations/[^/]+)/(apis|sharedflows)/([^/]+)/revisions/([^/]+)$`, f.deleteRevision)
+	f.route(http.MethodGet, `^(organizations/[^/]+)/(apis|sharedflows)/([^/]+)/deployments$`, f.listBundleDeployments)
+	f.route(http.MethodGet, `^(organizations/[^/]+)/environments/([^/]+)/deployments$`, f.listEnvironmentDeployments)
+	f.route(http.MethodGet, `^(organizations/[^/]+)/environments/([^/]+)/(apis|sharedflows)/([^/]+)/deployments$`, f.listEnvironmentBundleDeployments)
+	f.route(http.MethodPost, `^(organizations/[^/]+)/environments/([^/]+)/(apis|sharedflows)/([^/]+)/revisions/([^/]+)/deployments$`, f.deploy)
+	f.route(http.MethodGet, `^(organizations/[^/]+)/environments/([^/]+)/(apis|sharedflows)/([^/]+)/revisions/([^/]+)/deployments$`, f.getDeploymentStatus)
+	f.route(http.MethodDelete, `^(organizations/[^/]+)/environments/([^/]+)/(apis|sharedflows)/([^/]+)/revisions/([^/]+)/deployments$`, f.deleteItem)
+	f.route(http.MethodPost, `^(organizations/[^/]+)/environments/([^/]+)/apis/([^/]+)/revisions/([^/]+)/deployments:generateDeployChangeReport$`, f.generateDeployChangeReport)
+	//Environments, environment groups and attachments, which Google changes with long running operations
+	f.route(http.MethodPost, `^organizations/[^/]+/environments$`, f.createEnvironment)
+	f.route(http.MethodPut, `^organizations/[^/]+/environments/[^/]+$`, f.updateEnvironment)
+	f.route(http.MethodDelete, `^organizations/[^/]+/environments/[^/]+$`, f.deleteWithOperation)
+	f.route(http.MethodPost, `^organizations/[^/]+/envgroups$`, f.createEnvironmentGroup)
+	f.route(http.MethodPatch, `^organizations/[^/]+/envgroups/[^/]+$`, f.updateEnvironmentGroup)
+	f.route(http.MethodDelete, `^organizations/[^/]+/envgroups/[^/]+$`, f.deleteWithOperation)
+	f.route(http.MethodGet, `^organizations/[^/]+/instances$`, f.listInstances)
+	f.route(http.MethodGet, `^organizations/[^/]+/(envgroups|instances)/[^/]+/attachments$`, f.listAttachments)
+	f.route(http.MethodPost, `^(organizations/[^/]+)/(?:envgroups|instances)/[^/]+/attachments$`, f.createAttachment)
+	f.route(http.MethodDelete, `^organizations/[^/]+/(?:envgroups|instances)/[^/]+/attachments/[^/]+$`, f.deleteWithOperation)
+	//Flow hook points always exist, Edge attaches a shared flow with POST and Google with PUT
+	f.route(http.MethodGet, `^(organizations/[^/]+/environments/[^/]+)/flowhooks/([^/]+)$`, f.getFlowHook)
+	if f.google {
+		f.route(http.MethodPut, `^(organizations/[^/]+)/environments/[^/]+/flowhooks/([^/]+)$`, f.attachFlowHook)
+	} else {
+		f.route(http.MethodPost, `^(organizations/[^/]+)/environments/[^/]+/flowhooks/([^/]+)$`, f.attachFlowHook)
+	}
+	f.route(http.MethodDelete, `^organizations/[^/]+/environments/[^/]+/flowhooks/[^/]+$`, f.deleteItem)
+	//Keystore aliases are uploaded as files
+	f.route(http.MethodPost, `^organizations/[^/]+/environments/[^/]+/keystores/[^/]+/aliases$`, f.createAlias)
+	f.route(http.MethodPut, `^organizations/[^/]+/environments/[^/]+/keystores/[^/]+/aliases/[^/]+$`, f.updateAlias)
+}
+
+func (f *fakeApigee) generic(req *fakeRequest) (int, interface{}) {
+	//Paths alternate between collections and items, so an odd number of segments is a collection
+	if isCollection(req.path) {
+		switch req.Method {
+		case http.MethodGet:
+			return f.list(req.path)
+		case http.MethodPost:
+			doc, err := decodeDoc(req.body)
+			if err != nil {
+				return http.StatusBadRequest, err.Error()
+			}
+			field := nameField(req.path)
+			name, _ := doc[field].(string)
+			if name == "" {
+				return http.StatusBadRequest, field + " is required"
+			}
+			return f.create(req.path, name, doc)
+		}
+		return http.StatusMethodNotAllowed, req.Method + " is not supported on " + req.path
+	}
+	switch req.Method {
+	case http.MethodGet:
+		return f.getItem(req)
+	case http.MethodPut:
+		return f.replaceItem(req)
+	case http.MethodDelete:
+		return f.deleteItem(req)
+	}
+	return http.StatusMethodNotAllowed, req.Method + " is not supported on " + req.path
+}
+
+func (f *fakeApigee) list(collection string) (int, interface{}) {
+	parent := parentPath(collection)
+	if _, found := f.docs[parent]; (parent != "") && !found {
+		return http.StatusNotFound, parent + " does not exist"
+	}
+	return http.StatusOK, f.children(collection)
+}
+
+func (f *fakeApigee) create(collection string, name string, doc map[string]interface{}) (int, interface{}) {
+	parent := parentPath(collection)
+	if _, found := f.docs[parent]; (parent != "") && !found {
+		return http.StatusNotFound, parent + " does not exist"
+	}
+	path := collection + "/" + name
+	if _, found := f.docs[path]; found {
+		return http.StatusConflict, path + " already exists"
+	}
+	f.docs[path] = doc
+	return http.StatusCreated, doc
+}
+
+func (f *fakeApigee) getItem(req *fakeRequest) (int, interface{}) {
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) replaceItem(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[req.path]; !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	//Changing the name field moves the entity, along with everything under it
+	collection := parentPath(req.path)
+	field := nameField(collection)
+	path := req.path
+	name, _ := doc[field].(string)
+	if name == "" {
+		doc[field] = lastSegment(req.path)
+	} else if name != lastSegment(req.path) {
+		path = collection + "/" + name
+		if _, found := f.docs[path]; found {
+			return http.StatusConflict, path + " already exists"
+		}
+		f.moveTree(req.path, path)
+	}
+	f.docs[path] = doc
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) deleteItem(req *fakeRequest) (int, interface{}) {
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	f.removeTree(req.path)
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) createRoles(req *fakeRequest) (int, interface{}) {
+	roles := struct {
+		Roles []map[string]interface{} `json:"role"`
+	}{}
+	err := json.Unmarshal(req.body, &roles)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	for _, role := range roles.Roles {
+		name, _ := role["name"].(string)
+		status, out := f.create(req.path, name, role)
+		if status != http.StatusCreated {
+			return status, out
+		}
+	}
+	return http.StatusCreated, roles
+}
+
+func (f *fakeApigee) addUserRole(req *fakeRequest) (int, interface{}) {
+	form, err := url.ParseQuery(string(req.body))
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	emailId := form.Get("id")
+	if _, found := f.docs[fmt.Sprintf(client.UserPathGet, emailId)]; !found {
+		return http.StatusNotFound, "user " + emailId + " does not exist"
+	}
+	return f.create(req.path, emailId, map[string]interface{}{
+		"emailId": emailId,
+	})
+}
+
+func (f *fakeApigee) getRolePermission(req *fakeRequest) (int, interface{}) {
+	//Permissions are kept under the role, keyed by the resource path they apply to
+	doc, found := f.docs[req.path+req.URL.Query().Get("path")]
+	if !found {
+		return http.StatusNotFound, "no permissions for " + req.URL.Query().Get("path")
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) addRolePermission(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	permission := client.RolePermission{}
+	err := json.Unmarshal(req.body, &permission)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if !strings.HasPrefix(permission.Path, "/") {
+		return http.StatusBadRequest, "path must start with /"
+	}
+	key := req.path + permission.Path
+	permissions := []interface{}{}
+	if doc, found := f.docs[key]; found {
+		permissions = doc["permissions"].([]interface{})
+	}
+	for _, p := range permission.Permissions {
+		if !containsValue(permissions, p) {
+			permissions = append(permissions, p)
+		}
+	}
+	f.docs[key] = map[string]interface{}{
+		"path":        permission.Path,
+		"permissions": permissions,
+	}
+	return http.StatusCreated, f.docs[key]
+}
+
+func (f *fakeApigee) removeRolePermission(req *fakeRequest) (int, interface{}) {
+	key := req.match[1] + req.URL.Query().Get("path")
+	doc, found := f.docs[key]
+	if !found || !containsValue(doc["permissions"].([]interface{}), req.match[2]) {
+		return http.StatusNotFound, "permission " + req.match[2] + " is not granted"
+	}
+	permissions := []interface{}{}
+	for _, p := range doc["permissions"].([]interface{}) {
+		if p != req.match[2] {
+			permissions = append(permissions, p)
+		}
+	}
+	if len(permissions) == 0 {
+		delete(f.docs, key)
+	} else {
+		doc["permissions"] = permissions
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) listCompanyDevelopers(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	return http.StatusOK, map[string]interface{}{
+		"developer": f.childDocs(req.path),
+	}
+}
+
+func (f *fakeApigee) setCompanyDevelopers(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	developers := struct {
+		Developers []map[string]interface{} `json:"developer"`
+	}{}
+	err := json.Unmarshal(req.body, &developers)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	//Posting a developer that is already a member changes its role
+	for _, developer := range developers.Developers {
+		email, _ := developer["email"].(string)
+		if _, found := f.docs[req.match[1]+"/developers/"+email]; !found {
+			return http.StatusNotFound, "developer " + email + " does not exist"
+		}
+		f.docs[req.path+"/"+email] = developer
+	}
+	return http.StatusCreated, developers
+}
+
+func (f *fakeApigee) createApp(req *fakeRequest) (int, interface{}) {
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	name, _ := doc["name"].(string)
+	status, out := f.create(req.path, name, doc)
+	if status != http.StatusCreated {
+		return status, out
+	}
+	//Apigee generates a credential for every new app
+	credential := f.newCredential(f.nextName("key"), f.nextName("secret"))
+	f.docs[req.path+"/"+name+"/keys/"+credential["consumerKey"].(string)] = credential
+	app := map[string]interface{}{}
+	for key, value := range doc {
+		app[key] = value
+	}
+	app["credentials"] = []interface{}{credential}
+	return http.StatusCreated, app
+}
+
+func (f *fakeApigee) newCredential(consumerKey string, consumerSecret string) map[string]interface{} {
+	return map[string]interface{}{
+		"consumerKey":    consumerKey,
+		"consumerSecret": consumerSecret,
+		"status":         "approved",
+		"apiProducts":    []interface{}{},
+		"scopes":         []interface{}{},
+		"attributes":     []interface{}{},
+	}
+}
+
+func (f *fakeApigee) createCredential(req *fakeRequest) (int, interface{}) {
+	keys := parentPath(req.path)
+	if _, found := f.docs[parentPath(keys)]; !found {
+		return http.StatusNotFound, parentPath(keys) + " does not exist"
+	}
+	in := client.AppCredentialModify{}
+	err := json.Unmarshal(req.body, &in)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if (in.ConsumerKey == "") || (in.ConsumerSecret == "") {
+		return http.StatusBadRequest, "consumerKey and consumerSecret are required"
+	}
+	path := keys + "/" + in.ConsumerKey
+	if _, found := f.docs[path]; found {
+		return http.StatusConflict, "key " + in.ConsumerKey + " already exists"
+	}
+	credential := f.newCredential(in.ConsumerKey, in.ConsumerSecret)
+	f.docs[path] = credential
+	return f.updateCredential(req.path, credential, in)
+}
+
+func (f *fakeApigee) addCredentialProducts(req *fakeRequest) (int, interface{}) {
+	credential, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	in := client.AppCredentialModify{}
+	err := json.Unmarshal(req.body, &in)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	return f.updateCredential(req.path, credential, in)
+}
+
+func (f *fakeApigee) updateCredential(path string, credential map[string]interface{}, in client.AppCredentialModify) (int, interface{}) {
+	//Products are only ever added here, removing one has its own call
+	products := credential["apiProducts"].([]interface{})
+	for _, product := range in.APIProducts {
+		if _, found := f.docs[fmt.Sprintf(client.ProductPathGet, orgName(path), product)]; !found {
+			return http.StatusBadRequest, "API product " + product + " does not exist"
+		}
+		if findProduct(products, product) < 0 {
+			products = append(products, map[string]interface{}{
+				"apiproduct": product,
+				"status":     "approved",
+			})
+		}
+	}
+	credential["apiProducts"] = products
+	if in.Attributes != nil {
+		credential["attributes"] = toInterface(in.Attributes)
+	}
+	return http.StatusOK, credential
+}
+
+func (f *fakeApigee) setCredentialScopes(req *fakeRequest) (int, interface{}) {
+	credential, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	in := client.AppCredentialModify{}
+	err := json.Unmarshal(req.body, &in)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	scopes := []interface{}{}
+	for _, scope := range in.Scopes {
+		scopes = append(scopes, scope)
+	}
+	credential["scopes"] = scopes
+	return http.StatusOK, credential
+}
+
+func (f *fakeApigee) removeCredentialProduct(req *fakeRequest) (int, interface{}) {
+	credential, found := f.docs[req.match[1]]
+	if !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	products := credential["apiProducts"].([]interface{})
+	i := findProduct(products, req.match[2])
+	if i < 0 {
+		return http.StatusNotFound, "API product " + req.match[2] + " is not on the key"
+	}
+	credential["apiProducts"] = append(products[:i:i], products[i+1:]...)
+	return http.StatusOK, credential
+}
+
+func (f *fakeApigee) listKVMEntries(req *fakeRequest) (int, interface{}) {
+	kvm, found := f.docs[req.match[1]]
+	if !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	entries, _ := kvm["entry"].([]interface{})
+	if entries == nil {
+		entries = []interface{}{}
+	}
+	return http.StatusOK, map[string]interface{}{
+		"keyValueEntries": entries,
+		"nextPageToken":   "",
+	}
+}
+
+func (f *fakeApigee) createKVMEntry(req *fakeRequest) (int, interface{}) {
+	kvm, found := f.docs[req.match[1]]
+	if !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	entry, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	entries, _ := kvm["entry"].([]interface{})
+	name, _ := entry["name"].(string)
+	if findEntry(entries, name) >= 0 {
+		return http.StatusConflict, "entry " + name + " already exists"
+	}
+	kvm["entry"] = append(entries, entry)
+	return http.StatusCreated, entry
+}
+
+func (f *fakeApigee) updateKVMEntry(req *fakeRequest) (int, interface{}) {
+	kvm, found := f.docs[req.match[1]]
+	if !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	entry, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	entries, _ := kvm["entry"].([]interface{})
+	i := findEntry(entries, req.match[2])
+	if i < 0 {
+		return http.StatusNotFound, "entry " + req.match[2] + " does not exist"
+	}
+	entries[i] = entry
+	return http.StatusOK, entry
+}
+
+func (f *fakeApigee) deleteKVMEntry(req *fakeRequest) (int, interface{}) {
+	kvm, found := f.docs[req.match[1]]
+	if !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	entries, _ := kvm["entry"].([]interface{})
+	i := findEntry(entries, req.match[2])
+	if i < 0 {
+		return http.StatusNotFound, "entry " + req.match[2] + " does not exist"
+	}
+	entry := entries[i]
+	kvm["entry"] = append(entries[:i:i], entries[i+1:]...)
+	return http.StatusOK, entry
+}
+
+func (f *fakeApigee) createResourceFile(req *fakeRequest) (int, interface{}) {
+	rtype := req.URL.Query().Get("type")
+	name := req.URL.Query().Get("name")
+	if (rtype == "") || (name == "") {
+		return http.StatusBadRequest, "type and name are required"
+	}
+	if _, found := req.formFile("file"); !found {
+		return http.StatusBadRequest, "file is required"
+	}
+	if _, found := f.docs[req.match[1]]; !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	path := req.path + "/" + rtype + "/" + name
+	if _, found := f.docs[path]; found {
+		return http.StatusConflict, path + " already exists"
+	}
+	f.docs[path] = map[string]interface{}{
+		"type": rtype,
+		"name": name,
+	}
+	return http.StatusCreated, f.docs[path]
+}
+
+func (f *fakeApigee) listResourceFiles(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[req.match[1]]; !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	return http.StatusOK, map[string]interface{}{
+		"resourceFile": f.childDocs(req.path),
+	}
+}
+
+func (f *fakeApigee) updateResourceFile(req *fakeRequest) (int, interface{}) {
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	if _, found := req.formFile("file"); !found {
+		return http.StatusBadRequest, "file is required"
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) createPolicy(req *fakeRequest) (int, interface{}) {
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), client.ApplicationXml) {
+		return http.StatusBadRequest, "policies must be uploaded as XML"
+	}
+	name := xmlRootName(req.body)
+	if name == "" {
+		return http.StatusBadRequest, "policy must have a name"
+	}
+	return f.create(req.path, name, map[string]interface{}{
+		"name": name,
+	})
+}
+
+func (f *fakeApigee) listProxies(req *fakeRequest) (int, interface{}) {
+	if !f.google {
+		return f.list(req.path)
+	}
+	proxies := []interface{}{}
+	for _, name := range f.children(req.path) {
+		proxies = append(proxies, map[string]interface{}{
+			"name": name,
+		})
+	}
+	return http.StatusOK, map[string]interface{}{
+		"proxies": proxies,
+	}
+}
+
+func (f *fakeApigee) importBundle(req *fakeRequest) (int, interface{}) {
+	query := req.URL.Query()
+	if (query.Get("action") != "import") || (query.Get("name") == "") {
+		return http.StatusBadRequest, "action=import and name are required"
+	}
+	data, found := req.formFile("bundle")
+	if !found {
+		return http.StatusBadRequest, "bundle is required"
+	}
+	root := client.ProxyBundleRoot
+	if req.match[1] == "sharedflows" {
+		root = client.SharedFlowBundleRoot
+	}
+	files, err := client.ReadBundleZipData(data, root)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	//Every import adds the next revision
+	name := query.Get("name")
+	parent := req.path + "/" + name
+	if _, found := f.docs[parent]; !found {
+		f.docs[parent] = map[string]interface{}{
+			"name": name,
+		}
+	}
+	revision := f.nextRevision(parent)
+	path := parent + "/revisions/" + strconv.Itoa(revision)
+	f.docs[path] = f.revisionDetail(name, revision, root, files)
+	f.bundles[path] = data
+	return http.StatusCreated, f.docs[path]
+}
+
+func (f *fakeApigee) nextRevision(parent string) int {
+	revision := 0
+	for _, name := range f.children(parent + "/revisions") {
+		r, _ := strconv.Atoi(name)
+		if r > revision {
+			revision = r
+		}
+	}
+	return revision + 1
+}
+
+func (f *fakeApigee) revisionDetail(name string, revision int, root string, files map[string][]byte) map[string]interface{} {
+	basePaths := []string{}
+	proxyEndpoints := []string{}
+	targetEndpoints := []string{}
+	policies := []string{}
+	resources := []string{}
+	description := ""
+	filenames := []string{}
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		rel := strings.TrimPrefix(filename, root+"/")
+		dir := ""
+		base := rel
+		if i := strings.LastIndex(rel, "/"); i >= 0 {
+			dir = rel[:i]
+			base = rel[i+1:]
+		}
+		entity := strings.TrimSuffix(base, ".xml")
+		switch {
+		case dir == "":
+			if match := fakeDescription.FindSubmatch(files[filename]); match != nil {
+				description = string(match[1])
+			}
+		case dir == "proxies":
+			proxyEndpoints = append(proxyEndpoints, entity)
+			for _, match := range fakeBasePath.FindAllSubmatch(files[filename], -1) {
+				basePaths = append(basePaths, string(match[1]))
+			}
+		case dir == "targets":
+			targetEndpoints = append(targetEndpoints, entity)
+		case dir == "policies":
+			policies = append(policies, entity)
+		case strings.HasPrefix(dir, "resources/"):
+			resources = append(resources, strings.TrimPrefix(dir, "resources/")+"://"+base)
+		}
+	}
+	//Edge returns timestamps as numbers and Google as strings
+	var now interface{} = time.Now().UnixNano() / int64(time.Millisecond)
+	if f.google {
+		now = strconv.FormatInt(now.(int64), 10)
+	}
+	return map[string]interface{}{
+		"name":            name,
+		"revision":        strconv.Itoa(revision),
+		"description":     description,
+		"basepaths":       basePaths,
+		"proxyEndpoints":  proxyEndpoints,
+		"targetEndpoints": targetEndpoints,
+		"policies":        policies,
+		"resources":       resources,
+		"createdAt":       now,
+		"lastModifiedAt":  now,
+	}
+}
+
+func (f *fakeApigee) getBundle(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[req.path]; !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	//Revisions are listed as strings in alphabetical order
+	return http.StatusOK, map[string]interface{}{
+		"name":     lastSegment(req.path),
+		"revision": f.children(req.path + "/revisions"),
+	}
+}
+
+func (f *fakeApigee) deleteBundle(req *fakeRequest) (int, interface{}) {
+	if len(f.deployments(req.match[1], "", req.match[2], req.match[3], "")) > 0 {
+		return http.StatusBadRequest, req.match[3] + " cannot be deleted while it is deployed"
+	}
+	return f.deleteItem(req)
+}
+
+func (f *fakeApigee) getRevision(req *fakeRequest) (int, interface{}) {
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	if req.URL.Query().Get("format") == "bundle" {
+		data, found := f.bundles[req.path]
+		if !found {
+			return http.StatusNotFound, req.path + " has no bundle"
+		}
+		return http.StatusOK, data
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) deleteRevision(req *fakeRequest) (int, interface{}) {
+	if len(f.deployments(req.match[1], "", req.match[2], req.match[3], req.match[4])) > 0 {
+		return http.StatusBadRequest, "revision " + req.match[4] + " of " + req.match[3] + " cannot be deleted while it is deployed"
+	}
+	return f.deleteItem(req)
+}
+
+func (f *fakeApigee) deployments(org string, env string, kind string, name string, revision string) []fakeDeployment {
+	//Deployments are kept under the environment as revisions/N/deployments, empty arguments match everything
+	retVal := []fakeDeployment{}
+	for path, doc := range f.docs {
+		match := fakeDeploymentKey.FindStringSubmatch(path)
+		if (match == nil) || ("organizations/"+match[1] != org) || (match[3] != kind) {
+			continue
+		}
+		if ((env != "") && (match[2] != env)) || ((name != "") && (match[4] != name)) || ((revision != "") && (match[5] != revision)) {
+			continue
+		}
+		serviceAccount, _ := doc["serviceAccount"].(string)
+		retVal = append(retVal, fakeDeployment{
+			path:           path,
+			environment:    match[2],
+			kind:           match[3],
+			name:           match[4],
+			revision:       match[5],
+			serviceAccount: serviceAccount,
+		})
+	}
+	sort.Slice(retVal, func(i, j int) bool {
+		return retVal[i].path < retVal[j].path
+	})
+	return retVal
+}
+
+func (f *fakeApigee) googleDeployments(deployments []fakeDeployment) map[string]interface{} {
+	//Google leaves out the list entirely when nothing is deployed
+	retVal := map[string]interface{}{}
+	list := []interface{}{}
+	for _, dep := range deployments {
+		list = append(list, map[string]interface{}{
+			"apiProxy":       dep.name,
+			"environment":    dep.environment,
+			"revision":       dep.revision,
+			"serviceAccount": dep.serviceAccount,
+			"state":          client.DeploymentStateReady,
+		})
+	}
+	if len(list) > 0 {
+		retVal["deployments"] = list
+	}
+	return retVal
+}
+
+func edgeRevisions(deployments []fakeDeployment) []interface{} {
+	retVal := []interface{}{}
+	for _, dep := range deployments {
+		retVal = append(retVal, map[string]interface{}{
+			"name":  dep.revision,
+			"state": client.EdgeDeploymentStateDeployed,
+		})
+	}
+	return retVal
+}
+
+func (f *fakeApigee) listBundleDeployments(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	deployments := f.deployments(req.match[1], "", req.match[2], req.match[3], "")
+	if f.google {
+		return http.StatusOK, f.googleDeployments(deployments)
+	}
+	environments := []interface{}{}
+	for len(deployments) > 0 {
+		env := deployments[0].environment
+		i := 0
+		for (i < len(deployments)) && (deployments[i].environment == env) {
+			i++
+		}
+		environments = append(environments, map[string]interface{}{
+			"name":     env,
+			"revision": edgeRevisions(deployments[:i]),
+		})
+		deployments = deployments[i:]
+	}
+	return http.StatusOK, map[string]interface{}{
+		"name":        req.match[3],
+		"environment": environments,
+	}
+}
+
+func (f *fakeApigee) listEnvironmentDeployments(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	kind := "apis"
+	if req.URL.Query().Get("sharedFlows") == "true" {
+		kind = "sharedflows"
+	}
+	deployments := f.deployments(req.match[1], req.match[2], kind, "", "")
+	if f.google {
+		return http.StatusOK, f.googleDeployments(deployments)
+	}
+	//Edge uses the proxy structure for shared flows too
+	bundles := []interface{}{}
+	for len(deployments) > 0 {
+		name := deployments[0].name
+		i := 0
+		for (i < len(deployments)) && (deployments[i].name == name) {
+			i++
+		}
+		bundles = append(bundles, map[string]interface{}{
+			"name":     name,
+			"revision": edgeRevisions(deployments[:i]),
+		})
+		deployments = deployments[i:]
+	}
+	return http.StatusOK, map[string]interface{}{
+		"name":     req.match[2],
+		"aPIProxy": bundles,
+	}
+}
+
+func (f *fakeApigee) listEnvironmentBundleDeployments(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[fmt.Sprintf(client.EnvironmentPathGet, orgName(req.path), req.match[2])]; !found {
+		return http.StatusNotFound, "environment " + req.match[2] + " does not exist"
+	}
+	deployments := f.deployments(req.match[1], req.match[2], req.match[3], req.match[4], "")
+	if f.google {
+		return http.StatusOK, f.googleDeployments(deployments)
+	}
+	//Edge reports a bundle that is not deployed to the environment as missing
+	if len(deployments) == 0 {
+		return http.StatusNotFound, req.match[4] + " is not deployed to environment " + req.match[2]
+	}
+	return http.StatusOK, map[string]interface{}{
+		"name":        req.match[4],
+		"environment": req.match[2],
+		"revision":    edgeRevisions(deployments),
+	}
+}
+
+func (f *fakeApigee) deploy(req *fakeRequest) (int, interface{}) {
+	org, env, kind, name, revision := req.match[1], req.match[2], req.match[3], req.match[4], req.match[5]
+	if _, found := f.docs[fmt.Sprintf(client.EnvironmentPathGet, orgName(org), env)]; !found {
+		return http.StatusNotFound, "environment " + env + " does not exist"
+	}
+	if _, found := f.docs[org+"/"+kind+"/"+name+"/revisions/"+revision]; !found {
+		return http.StatusNotFound, "revision " + revision + " of " + name + " does not exist"
+	}
+	form, err := url.ParseQuery(string(req.body))
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	serviceAccount := form.Get("serviceAccount")
+	if serviceAccount != "" {
+		if !f.google {
+			return http.StatusBadRequest, "serviceAccount is not supported"
+		}
+		serviceAccount = client.GoogleServiceAccountPrefix + serviceAccount
+	}
+	//Override swaps out every other revision, otherwise Edge deploys alongside them and Google refuses
+	override := (form.Get("override") == "true") || (req.URL.Query().Get("override") == "true")
+	for _, dep := range f.deployments(org, env, kind, name, "") {
+		if dep.revision == revision {
+			continue
+		}
+		if override {
+			delete(f.docs, dep.path)
+		} else if f.google {
+			return http.StatusBadRequest, "revision " + dep.revision + " of " + name + " is already deployed to environment " + env
+		}
+	}
+	f.docs[req.path] = map[string]interface{}{
+		"environment":    env,
+		"name":           name,
+		"revision":       revision,
+		"serviceAccount": serviceAccount,
+	}
+	return http.StatusOK, f.docs[req.path]
+}
+
+func (f *fakeApigee) getDeploymentStatus(req *fakeRequest) (int, interface{}) {
+	//Deployments finish right away so the provider never has to wait
+	if _, found := f.docs[req.path]; !found {
+		return http.StatusNotFound, "revision " + req.match[5] + " of " + req.match[4] + " is not deployed to environment " + req.match[2]
+	}
+	if f.google {
+		return http.StatusOK, map[string]interface{}{
+			"environment": req.match[2],
+			"apiProxy":    req.match[4],
+			"revision":    req.match[5],
+			"state":       client.DeploymentStateReady,
+			"pods": []interface{}{
+				map[string]interface{}{
+					"podName":          "runtime-0",
+					"deploymentStatus": "deployed",
+				},
+			},
+		}
+	}
+	return http.StatusOK, map[string]interface{}{
+		"environment": req.match[2],
+		"name":        req.match[5],
+		"state":       client.EdgeDeploymentStateDeployed,
+		"server": []interface{}{
+			map[string]interface{}{
+				"status": client.EdgeDeploymentStateDeployed,
+				"type":   []interface{}{"message-processor"},
+				"uUID":   "mp-0",
+				"pod": map[string]interface{}{
+					"name":   "gateway",
+					"region": "dc-1",
+				},
+			},
+		},
+	}
+}
+
+func (f *fakeApigee) generateDeployChangeReport(req *fakeRequest) (int, interface{}) {
+	if !f.google {
+		return http.StatusNotFound, "change reports are only supported by Google"
+	}
+	org, env, name, revision := req.match[1], req.match[2], req.match[3], req.match[4]
+	detail, found := f.docs[org+"/apis/"+name+"/revisions/"+revision]
+	if !found {
+		return http.StatusNotFound, "revision " + revision + " of " + name + " does not exist"
+	}
+	conflicts := []interface{}{}
+	for _, dep := range f.deployments(org, env, "apis", "", "") {
+		other := f.docs[org+"/apis/"+dep.name+"/revisions/"+dep.revision]
+		for _, basePath := range toStrings(detail["basepaths"]) {
+			if !containsValue(toInterface(toStrings(other["basepaths"])), basePath) {
+				continue
+			}
+			conflicts = append(conflicts, map[string]interface{}{
+				"description": fmt.Sprintf("basepath %s conflicts with revision %s of proxy %s", basePath, dep.revision, dep.name),
+				"conflictingDeployment": map[string]interface{}{
+					"apiProxy":    dep.name,
+					"basepath":    basePath,
+					"environment": env,
+					"revision":    dep.revision,
+				},
+			})
+		}
+	}
+	return http.StatusOK, map[string]interface{}{
+		"routingConflicts": conflicts,
+	}
+}
+
+func (f *fakeApigee) environmentDefaults(doc map[string]interface{}) {
+	//Google fills in what was left out and adds properties of its own
+	for key, value := range map[string]string{"deploymentType": "PROXY", "apiProxyType": "PROGRAMMABLE", "state": "ACTIVE"} {
+		if s, _ := doc[key].(string); s == "" {
+			doc[key] = value
+		}
+	}
+	properties, _ := doc["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		doc["properties"] = properties
+	}
+	list, _ := properties["property"].([]interface{})
+	if findEntry(list, fakeServerManagedProperty) < 0 {
+		properties["property"] = append(list, map[string]interface{}{
+			"name":  fakeServerManagedProperty,
+			"value": "true",
+		})
+	}
+}
+
+func (f *fakeApigee) operation(target string) map[string]interface{} {
+	//Operations finish right away but are still reported as running, so the provider has to poll them
+	name := fmt.Sprintf("organizations/%s/operations/%s", orgName(target), f.nextName("operation"))
+	f.docs[name] = map[string]interface{}{
+		"name": name,
+		"done": true,
+		"metadata": map[string]interface{}{
+			"state":              "FINISHED",
+			"targetResourceName": target,
+		},
+	}
+	return map[string]interface{}{
+		"name": name,
+		"done": false,
+		"metadata": map[string]interface{}{
+			"state":              "IN_PROGRESS",
+			"targetResourceName": target,
+		},
+	}
+}
+
+func (f *fakeApigee) createEnvironment(req *fakeRequest) (int, interface{}) {
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	name, _ := doc["name"].(string)
+	if name == "" {
+		return http.StatusBadRequest, "name is required"
+	}
+	if f.google {
+		f.environmentDefaults(doc)
+	}
+	status, out := f.create(req.path, name, doc)
+	if (status != http.StatusCreated) || !f.google {
+		return status, out
+	}
+	return http.StatusOK, f.operation(req.path + "/" + name)
+}
+
+func (f *fakeApigee) updateEnvironment(req *fakeRequest) (int, interface{}) {
+	if !f.google {
+		return f.replaceItem(req)
+	}
+	current, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	//The PUT replaces every property, but the settings that cannot change are kept
+	for _, key := range []string{"deploymentType", "apiProxyType", "state"} {
+		doc[key] = current[key]
+	}
+	doc["name"] = lastSegment(req.path)
+	f.docs[req.path] = doc
+	return http.StatusOK, f.operation(req.path)
+}
+
+func (f *fakeApigee) deleteWithOperation(req *fakeRequest) (int, interface{}) {
+	status, out := f.deleteItem(req)
+	if (status != http.StatusOK) || !f.google {
+		return status, out
+	}
+	return http.StatusOK, f.operation(req.path)
+}
+
+func (f *fakeApigee) createEnvironmentGroup(req *fakeRequest) (int, interface{}) {
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	name := req.URL.Query().Get("name")
+	if name == "" {
+		return http.StatusBadRequest, "name is required"
+	}
+	doc["name"] = name
+	doc["state"] = "ACTIVE"
+	status, out := f.create(req.path, name, doc)
+	if status != http.StatusCreated {
+		return status, out
+	}
+	return http.StatusOK, f.operation(req.path + "/" + name)
+}
+
+func (f *fakeApigee) updateEnvironmentGroup(req *fakeRequest) (int, interface{}) {
+	current, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	if req.URL.Query().Get("updateMask") != "hostnames" {
+		return http.StatusBadRequest, "only hostnames can be updated"
+	}
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	current["hostnames"] = doc["hostnames"]
+	return http.StatusOK, f.operation(req.path)
+}
+
+func (f *fakeApigee) listInstances(req *fakeRequest) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"instances": f.childDocs(req.path),
+	}
+}
+
+func (f *fakeApigee) listAttachments(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(req.path)]; !found {
+		return http.StatusNotFound, parentPath(req.path) + " does not exist"
+	}
+	key := "attachments"
+	if req.match[1] == "envgroups" {
+		key = "environmentGroupAttachments"
+	}
+	return http.StatusOK, map[string]interface{}{
+		key: f.childDocs(req.path),
+	}
+}
+
+func (f *fakeApigee) createAttachment(req *fakeRequest) (int, interface{}) {
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	env, _ := doc["environment"].(string)
+	if _, found := f.docs[req.match[1]+"/environments/"+env]; !found {
+		return http.StatusBadRequest, "environment " + env + " does not exist"
+	}
+	for _, attachment := range f.childDocs(req.path) {
+		if attachment.(map[string]interface{})["environment"] == env {
+			return http.StatusConflict, "environment " + env + " is already attached"
+		}
+	}
+	//Attachments are named by Apigee, not after the environment
+	name := f.nextName("attachment")
+	doc["name"] = name
+	status, out := f.create(req.path, name, doc)
+	if status != http.StatusCreated {
+		return status, out
+	}
+	return http.StatusOK, f.operation(req.path + "/" + name)
+}
+
+func (f *fakeApigee) getFlowHook(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[req.match[1]]; !found {
+		return http.StatusNotFound, req.match[1] + " does not exist"
+	}
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusOK, map[string]interface{}{
+			"flowHookPoint": req.match[2],
+		}
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) attachFlowHook(req *fakeRequest) (int, interface{}) {
+	if _, found := f.docs[parentPath(parentPath(req.path))]; !found {
+		return http.StatusNotFound, parentPath(parentPath(req.path)) + " does not exist"
+	}
+	doc, err := decodeDoc(req.body)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	sharedFlowName, _ := doc["sharedFlow"].(string)
+	if _, found := f.docs[fmt.Sprintf(client.SharedFlowPathGet, orgName(req.path), sharedFlowName)]; !found {
+		return http.StatusBadRequest, "shared flow " + sharedFlowName + " does not exist"
+	}
+	doc["flowHookPoint"] = req.match[2]
+	f.docs[req.path] = doc
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) createAlias(req *fakeRequest) (int, interface{}) {
+	alias := req.URL.Query().Get("alias")
+	if alias == "" {
+		return http.StatusBadRequest, "alias is required"
+	}
+	//A key and certificate pair is uploaded as separate files, the other formats as one
+	part := "file"
+	switch req.URL.Query().Get("format") {
+	case "keycertfile":
+		part = "certFile"
+	case "keycertjar", "pkcs12":
+	default:
+		return http.StatusBadRequest, "unsupported format " + req.URL.Query().Get("format")
+	}
+	if _, found := req.formFile(part); !found {
+		return http.StatusBadRequest, part + " is required"
+	}
+	return f.create(req.path, alias, map[string]interface{}{
+		"alias": alias,
+		"certsInfo": map[string]interface{}{
+			"certInfo": []interface{}{
+				map[string]interface{}{
+					"subject": "CN=" + alias,
+				},
+			},
+		},
+	})
+}
+
+func (f *fakeApigee) updateAlias(req *fakeRequest) (int, interface{}) {
+	doc, found := f.docs[req.path]
+	if !found {
+		return http.StatusNotFound, req.path + " does not exist"
+	}
+	if _, found := req.formFile("file"); !found {
+		return http.StatusBadRequest, "file is required"
+	}
+	return http.StatusOK, doc
+}
+
+func (f *fakeApigee) children(collection string) []string {
+	retVal := []string{}
+	for path := range f.docs {
+		if !strings.HasPrefix(path, collection+"/") {
+			continue
+		}
+		name := strings.TrimPrefix(path, collection+"/")
+		if !strings.Contains(name, "/") {
+			retVal = append(retVal, name)
+		}
+	}
+	sort.Strings(retVal)
+	return retVal
+}
+
+func (f *fakeApigee) childDocs(collection string) []interface{} {
+	retVal := []interface{}{}
+	for _, name := range f.children(collection) {
+		retVal = append(retVal, f.docs[collection+"/"+name])
+	}
+	return retVal
+}
+
+func (f *fakeApigee) removeTree(path string) {
+	for key := range f.docs {
+		if (key == path) || strings.HasPrefix(key, path+"/") {
+			delete(f.docs, key)
+		}
+	}
+	for key := range f.bundles {
+		if (key == path) || strings.HasPrefix(key, path+"/") {
+			delete(f.bundles, key)
+		}
+	}
+}
+
+func (f *fakeApigee) moveTree(from string, to string) {
+	for key, doc := range f.docs {
+		if (key == from) || strings.HasPrefix(key, from+"/") {
+			delete(f.docs, key)
+			f.docs[to+strings.TrimPrefix(key, from)] = doc
+		}
+	}
+}
+
+func (f *fakeApigee) nextName(prefix string) string {
+	f.sequence++
+	return fmt.Sprintf("%s-%d", prefix, f.sequence)
+}
+
+func (req *fakeRequest) formFile(name string) ([]byte, bool) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, false
+	}
+	reader := multipart.NewReader(bytes.NewReader(req.body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, false
+		}
+		if part.FormName() == name {
+			data, err := ioutil.ReadAll(part)
+			return data, err == nil
+		}
+	}
+}
+
+func isCollection(path string) bool {
+	return len(strings.Split(path, "/"))%2 == 1
+}
+
+func parentPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+func lastSegment(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func orgName(path string) string {
+	segments := strings.Split(path, "/")
+	if (len(segments) < 2) || (segments[0] != "organizations") {
+		return ""
+	}
+	return segments[1]
+}
+
+func nameField(collection string) string {
+	//Users and developers are named by their email address
+	switch lastSegment(collection) {
+	case "users":
+		return "emailId"
+	case "developers":
+		return "email"
+	}
+	return "name"
+}
+
+func decodeDoc(body []byte) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	err := json.Unmarshal(body, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func xmlRootName(data []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "name" {
+					return attr.Value
+				}
+			}
+			return ""
+		}
+	}
+}
+
+func containsValue(values []interface{}, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func findEntry(entries []interface{}, name string) int {
+	for i, entry := range entries {
+		if e, ok := entry.(map[string]interface{}); ok && (e["name"] == name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func findProduct(products []interface{}, name string) int {
+	for i, product := range products {
+		if p, ok := product.(map[string]interface{}); ok && (p["apiproduct"] == name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func toStrings(value interface{}) []string {
+	retVal := []string{}
+	switch values := value.(type) {
+	case []string:
+		retVal = append(retVal, values...)
+	case []interface{}:
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				retVal = append(retVal, s)
+			}
+		}
+	}
+	return retVal
+}
+
+func toInterface(value interface{}) []interface{} {
+	//Round trip through JSON so stored documents only hold plain maps, slices and values
+	retVal := []interface{}{}
+	data, _ := json.Marshal(value)
+	json.Unmarshal(data, &retVal)
+	return retVal
+}
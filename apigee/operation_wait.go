@@ -0,0 +1,33 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"time"
+)
+
+const (
+	DefaultOperationTimeout = 20 * time.Minute
+	OperationPollInterval   = 10 * time.Second
+)
+
+func waitForOperation(ctx context.Context, c *client.Client, op *client.LongRunningOperation, timeout time.Duration) error {
+	//Edge calls are synchronous so there is nothing to wait for
+	if (op == nil) || (op.Name == "") {
+		return nil
+	}
+	return pollUntil(ctx, timeout, OperationPollInterval, "operation "+op.Name, func() (bool, error) {
+		current, err := c.GetOperation(op.Name)
+		if err != nil {
+			return false, err
+		}
+		if !current.Done {
+			return false, nil
+		}
+		if current.Error != nil {
+			return false, fmt.Errorf("operation %s failed: %d: %s", op.Name, current.Error.Code, current.Error.Message)
+		}
+		return true, nil
+	})
+}
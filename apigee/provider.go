@@ -31,7 +31,20 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				Sensitive:     true,
 				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_ACCESS_TOKEN", nil),
-				ConflictsWith: []string{"username", "password", "oauth_server"},
+				ConflictsWith: []string{"username", "password", "oauth_server", "service_account_key", "service_account_key_file"},
+			},
+			"service_account_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_SERVICE_ACCOUNT_KEY", nil),
+				ConflictsWith: []string{"username", "password", "access_token", "oauth_server", "service_account_key_file"},
+			},
+			"service_account_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_SERVICE_ACCOUNT_KEY_FILE", nil),
+				ConflictsWith: []string{"username", "password", "access_token", "oauth_server", "service_account_key"},
 			},
 			"use_ssl": {
 				Type:     schema.TypeBool,
@@ -59,7 +72,6 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_OAUTH_SERVER", nil),
 				ConflictsWith: []string{"access_token"},
-				RequiredWith:  []string{"username", "password"},
 			},
 			"oauth_server_path": {
 				Type:        schema.TypeString,
@@ -72,45 +84,106 @@ func Provider() *schema.Provider {
 				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_OAUTH_PORT", 443),
 				ValidateFunc: validation.IntBetween(0, 65535),
 			},
+			"oauth_client_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_OAUTH_CLIENT_ID", nil),
+				RequiredWith: []string{"oauth_server", "oauth_client_secret"},
+			},
+			"oauth_client_secret": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_OAUTH_CLIENT_SECRET", nil),
+				RequiredWith: []string{"oauth_server", "oauth_client_id"},
+			},
+			"mfa_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_MFA_TOKEN", nil),
+				ConflictsWith: []string{"passcode"},
+				RequiredWith:  []string{"oauth_server", "username", "password"},
+			},
+			"passcode": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("APIGEE_PASSCODE", nil),
+				ConflictsWith: []string{"username", "password", "mfa_token"},
+				RequiredWith:  []string{"oauth_server"},
+			},
 			"organization": {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("APIGEE_ORGANIZATION", nil),
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_MAX_RETRIES", client.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_base_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_RETRY_BASE_BACKOFF", client.DefaultBaseBackoff),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APIGEE_RETRY_MAX_BACKOFF", client.DefaultMaxBackoff),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"apigee_user":                       resourceUser(),
-			"apigee_role":                       resourceRole(),
-			"apigee_user_role":                  resourceUserRole(),
-			"apigee_role_permission":            resourceRolePermission(),
-			"apigee_cache":                      resourceCache(),
-			"apigee_organization_kvm":           resourceOrganizationKVM(),
-			"apigee_environment_kvm":            resourceEnvironmentKVM(),
-			"apigee_proxy_kvm":                  resourceProxyKVM(),
-			"apigee_target_server":              resourceTargetServer(),
-			"apigee_virtual_host":               resourceVirtualHost(),
-			"apigee_proxy":                      resourceProxy(),
-			"apigee_proxy_deployment":           resourceProxyDeployment(),
-			"apigee_shared_flow":                resourceSharedFlow(),
-			"apigee_shared_flow_deployment":     resourceSharedFlowDeployment(),
-			"apigee_developer":                  resourceDeveloper(),
-			"apigee_product":                    resourceProduct(),
-			"apigee_company":                    resourceCompany(),
-			"apigee_company_developer":          resourceCompanyDeveloper(),
-			"apigee_developer_app":              resourceDeveloperApp(),
-			"apigee_developer_app_credential":   resourceDeveloperAppCredential(),
-			"apigee_company_app":                resourceCompanyApp(),
-			"apigee_company_app_credential":     resourceCompanyAppCredential(),
-			"apigee_organization_resource_file": resourceOrganizationResourceFile(),
-			"apigee_environment_resource_file":  resourceEnvironmentResourceFile(),
-			"apigee_proxy_resource_file":        resourceProxyResourceFile(),
-			"apigee_proxy_policy":               resourceProxyPolicy(),
-			"apigee_reference":                  resourceReference(),
-			"apigee_keystore":                   resourceKeystore(),
-			"apigee_alias":                      resourceAlias(),
+			"apigee_user":                         resourceUser(),
+			"apigee_role":                         resourceRole(),
+			"apigee_user_role":                    resourceUserRole(),
+			"apigee_role_permission":              resourceRolePermission(),
+			"apigee_cache":                        resourceCache(),
+			"apigee_organization_kvm":             resourceOrganizationKVM(),
+			"apigee_environment_kvm":              resourceEnvironmentKVM(),
+			"apigee_proxy_kvm":                    resourceProxyKVM(),
+			"apigee_target_server":                resourceTargetServer(),
+			"apigee_virtual_host":                 resourceVirtualHost(),
+			"apigee_proxy":                        resourceProxy(),
+			"apigee_proxy_deployment":             resourceProxyDeployment(),
+			"apigee_proxy_canary_deployment":      resourceProxyCanaryDeployment(),
+			"apigee_environment":                  resourceEnvironment(),
+			"apigee_environment_group":            resourceEnvironmentGroup(),
+			"apigee_environment_group_attachment": resourceEnvironmentGroupAttachment(),
+			"apigee_instance_attachment":          resourceInstanceAttachment(),
+			"apigee_shared_flow":                  resourceSharedFlow(),
+			"apigee_shared_flow_deployment":       resourceSharedFlowDeployment(),
+			"apigee_flow_hook":                    resourceFlowHook(),
+			"apigee_developer":                    resourceDeveloper(),
+			"apigee_product":                      resourceProduct(),
+			"apigee_company":                      resourceCompany(),
+			"apigee_company_developer":            resourceCompanyDeveloper(),
+			"apigee_developer_app":                resourceDeveloperApp(),
+			"apigee_developer_app_credential":     resourceDeveloperAppCredential(),
+			"apigee_company_app":                  resourceCompanyApp(),
+			"apigee_company_app_credential":       resourceCompanyAppCredential(),
+			"apigee_organization_resource_file":   resourceOrganizationResourceFile(),
+			"apigee_environment_resource_file":    resourceEnvironmentResourceFile(),
+			"apigee_proxy_resource_file":          resourceProxyResourceFile(),
+			"apigee_proxy_policy":                 resourceProxyPolicy(),
+			"apigee_shared_flow_resource_file":    resourceSharedFlowResourceFile(),
+			"apigee_shared_flow_policy":           resourceSharedFlowPolicy(),
+			"apigee_reference":                    resourceReference(),
+			"apigee_keystore":                     resourceKeystore(),
+			"apigee_alias":                        resourceAlias(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"apigee_user": dataSourceUser(),
+			"apigee_user":                    dataSourceUser(),
+			"apigee_proxy":                   dataSourceProxy(),
+			"apigee_proxies":                 dataSourceProxies(),
+			"apigee_environment_deployments": dataSourceEnvironmentDeployments(),
+			"apigee_proxy_bundle":            dataSourceProxyBundle(),
+			"apigee_shared_flow_bundle":      dataSourceSharedFlowBundle(),
+			"apigee_instances":               dataSourceInstances(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -120,6 +193,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
 	accessToken := d.Get("access_token").(string)
+	serviceAccountKey := d.Get("service_account_key").(string)
+	serviceAccountKeyFile := d.Get("service_account_key_file").(string)
 	useSSL := d.Get("use_ssl").(bool)
 	server := d.Get("server").(string)
 	serverPath := d.Get("server_path").(string)
@@ -127,15 +202,40 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	oauthServer := d.Get("oauth_server").(string)
 	oauthServerPath := d.Get("oauth_server_path").(string)
 	oauthPort := d.Get("oauth_port").(int)
+	oauthClientId := d.Get("oauth_client_id").(string)
+	oauthClientSecret := d.Get("oauth_client_secret").(string)
+	mfaToken := d.Get("mfa_token").(string)
+	passcode := d.Get("passcode").(string)
 	organization := d.Get("organization").(string)
+	maxRetries := d.Get("max_retries").(int)
+	retryBaseBackoff := d.Get("retry_base_backoff").(int)
+	retryMaxBackoff := d.Get("retry_max_backoff").(int)
 
 	//Check for valid authentication
-	if (username == "") && (password == "") && (accessToken == "") {
-		return nil, diag.Errorf("You must specify either username/password for Basic Authentication, username/password/oauth_server for OAuth Authentication, or access_token")
+	if (username == "") && (password == "") && (accessToken == "") && (serviceAccountKey == "") && (serviceAccountKeyFile == "") && (passcode == "") {
+		return nil, diag.Errorf("You must specify either username/password for Basic Authentication, username/password/oauth_server or passcode/oauth_server for OAuth Authentication, service_account_key/service_account_key_file for Google Cloud Authentication, or access_token")
+	}
+	if (oauthServer != "") && (passcode == "") && ((username == "") || (password == "")) {
+		return nil, diag.Errorf("oauth_server requires either username/password or passcode")
+	}
+	//Google OAuth tokens must never be sent to any other server
+	if ((serviceAccountKey != "") || (serviceAccountKeyFile != "")) && (server != client.GoogleApigeeServer) {
+		return nil, diag.Errorf("service_account_key/service_account_key_file can only be used when server is %s", client.GoogleApigeeServer)
+	}
+	if serviceAccountKeyFile != "" {
+		buf, err := client.GetBuffer(serviceAccountKeyFile)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		serviceAccountKey = buf.String()
+	}
+
+	if retryBaseBackoff > retryMaxBackoff {
+		return nil, diag.Errorf("retry_base_backoff cannot be greater than retry_max_backoff")
 	}
 
 	var diags diag.Diagnostics
-	c, err := client.NewClient(username, password, accessToken, useSSL, server, serverPath, port, oauthServer, oauthServerPath, oauthPort, organization)
+	c, err := client.NewClient(username, password, accessToken, useSSL, server, serverPath, port, oauthServer, oauthServerPath, oauthPort, organization, maxRetries, retryBaseBackoff, retryMaxBackoff, serviceAccountKey, oauthClientId, oauthClientSecret, mfaToken, passcode)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -0,0 +1,163 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testAccOrganization = "testorg"
+	testAccEnvironment  = "test"
+)
+
+func TestProvider(t *testing.T) {
+	err := Provider().InternalValidate()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderServiceAccountKeyRequiresGoogle(t *testing.T) {
+	tests := []struct {
+		server   string
+		expected string
+	}{
+		{"", "can only be used when server is " + client.GoogleApigeeServer},
+		{"edge.example.com", "can only be used when server is " + client.GoogleApigeeServer},
+		{client.GoogleApigeeServer, ""},
+	}
+	for _, test := range tests {
+		raw := map[string]interface{}{
+			"service_account_key": "not a key",
+			"organization":        testAccOrganization,
+		}
+		if test.server != "" {
+			raw["server"] = test.server
+		}
+		d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+		_, diags := providerConfigure(context.Background(), d)
+		message := ""
+		for _, diagnostic := range diags {
+			message += diagnostic.Summary
+		}
+		if test.expected == "" {
+			//The key itself is rejected, but only after the server check
+			if strings.Contains(message, "can only be used when server is") {
+				t.Errorf("server %q: expected the server to be accepted, got %s", test.server, message)
+			}
+		} else if !strings.Contains(message, test.expected) {
+			t.Errorf("server %q: expected %q, got %q", test.server, test.expected, message)
+		}
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	//Acceptance tests run a real terraform binary against the fake management API
+	if (os.Getenv("TF_ACC_TERRAFORM_PATH") != "") || (os.Getenv("TF_ACC_TERRAFORM_VERSION") != "") {
+		return
+	}
+	_, err := exec.LookPath("terraform")
+	if err != nil {
+		t.Skip("terraform binary not found, set TF_ACC_TERRAFORM_PATH to run acceptance tests")
+	}
+}
+
+func testAccProviderFactories(fake *fakeApigee) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"apigee": func() (*schema.Provider, error) {
+			p := Provider()
+			configure := p.ConfigureContextFunc
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				m, diags := configure(ctx, d)
+				if diags.HasError() {
+					return nil, diags
+				}
+				m.(*client.Client).SetTransport(fake)
+				return m, diags
+			}
+			return p, nil
+		},
+	}
+}
+
+func testAccProviderConfig(fake *fakeApigee) string {
+	//The server name alone decides whether the client talks Edge or Google
+	if fake.google {
+		return fmt.Sprintf(`
+provider "apigee" {
+  server       = %q
+  access_token = "token"
+  organization = %q
+  max_retries  = 0
+}
+`, client.GoogleApigeeServer, testAccOrganization)
+	}
+	return fmt.Sprintf(`
+provider "apigee" {
+  server       = "edge.example.com"
+  username     = "user"
+  password     = "pass"
+  organization = %q
+  max_retries  = 0
+}
+`, testAccOrganization)
+}
+
+func testAccFlavors(t *testing.T, test func(t *testing.T, fake *fakeApigee)) {
+	//Edge and Google use different request and response shapes for the same resource
+	for _, google := range []bool{false, true} {
+		google := google
+		name := "edge"
+		if google {
+			name = "google"
+		}
+		t.Run(name, func(t *testing.T) {
+			test(t, newFakeApigee(t, google))
+		})
+	}
+}
+
+func testAccFixture(t *testing.T, name string) string {
+	path, err := filepath.Abs(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testAccCheckExists(fake *fakeApigee, resourceName string, path func(rs *terraform.ResourceState) string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		if !fake.exists(path(rs)) {
+			return fmt.Errorf("%s does not exist in the fake Apigee", path(rs))
+		}
+		return nil
+	}
+}
+
+func testAccCheckDestroy(fake *fakeApigee, resourceType string, path func(rs *terraform.ResourceState) string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if fake.exists(path(rs)) {
+				return fmt.Errorf("%s still exists in the fake Apigee", path(rs))
+			}
+		}
+		return nil
+	}
+}
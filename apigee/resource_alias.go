@@ -1,17 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
-	"strconv"
 )
 
 func resourceAlias() *schema.Resource {
@@ -115,23 +109,7 @@ func resourceAliasCreate(ctx context.Context, d *schema.ResourceData, m interfac
 	if ok {
 		fd["password"] = client.FormData{Text: password.(string)}
 	}
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(fd)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.AliasPath, c.Organization, newAlias.EnvironmentName, newAlias.KeystoreName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
-	}
-	requestQuery := url.Values{
-		"alias":                   []string{newAlias.Name},
-		"format":                  []string{newAlias.Format},
-		"ignoreExpiryValidation":  []string{strconv.FormatBool(newAlias.IgnoreExpiryValidation)},
-		"ignoreNewlineValidation": []string{strconv.FormatBool(newAlias.IgnoreNewlineValidation)},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err := c.CreateAlias(&newAlias, fd)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -144,12 +122,10 @@ func resourceAliasRead(ctx context.Context, d *schema.ResourceData, m interface{
 	var diags diag.Diagnostics
 	envName, keystoreName, name := client.AliasDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.AliasPathGet, c.Organization, envName, keystoreName, name)
-	_, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	_, err := c.GetAlias(envName, keystoreName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
@@ -169,22 +145,13 @@ func resourceAliasUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	c := m.(*client.Client)
 	//Only care about file changes
 	if d.HasChanges("file", "file_hash") {
-		file := d.Get("file").(string)
-		//Turn filename into multi part buffer
-		mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-			"file": client.FormData{Filename: file},
-		})
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		requestPath := fmt.Sprintf(client.AliasPathGet, c.Organization, envName, keystoreName, name)
-		requestHeaders := http.Header{
-			headers.ContentType: []string{mp.FormDataContentType()},
-		}
-		requestQuery := url.Values{
-			"ignoreExpiryValidation": []string{strconv.FormatBool(d.Get("ignore_expiry_validation").(bool))},
+		upAlias := client.Alias{
+			EnvironmentName:        envName,
+			KeystoreName:           keystoreName,
+			Name:                   name,
+			IgnoreExpiryValidation: d.Get("ignore_expiry_validation").(bool),
 		}
-		_, err = c.HttpRequest(http.MethodPut, requestPath, requestQuery, requestHeaders, buf)
+		err := c.UpdateAliasCertificate(&upAlias, d.Get("file").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -196,8 +163,7 @@ func resourceAliasDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	var diags diag.Diagnostics
 	envName, keystoreName, name := client.AliasDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.AliasPathGet, c.Organization, envName, keystoreName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteAlias(envName, keystoreName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
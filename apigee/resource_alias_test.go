@@ -0,0 +1,53 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccAlias(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_alias", testAccAliasPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_keystore" "test" {
+  environment_name = "test"
+  name             = "acctest"
+}
+
+resource "apigee_alias" "test" {
+  environment_name = "test"
+  keystore_name    = apigee_keystore.test.name
+  name             = "acctest"
+  format           = "keycertfile"
+  cert_file        = %q
+  cert_file_hash   = "1"
+}
+`, testAccFixture(t, "cert.pem")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_alias.test", testAccAliasPath),
+					resource.TestCheckResourceAttr("apigee_alias.test", "id", "test:acctest:acctest"),
+				),
+			},
+			{
+				ResourceName:      "apigee_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				//Only the certificate details can be read back, not what was uploaded
+				ImportStateVerifyIgnore: []string{"format", "file", "file_hash", "key_file", "key_file_hash", "cert_file", "cert_file_hash", "password"},
+			},
+		},
+	})
+}
+
+func testAccAliasPath(rs *terraform.ResourceState) string {
+	envName, keystoreName, name := client.AliasDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.AliasPathGet, testAccOrganization, envName, keystoreName, name)
+}
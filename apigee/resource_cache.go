@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 	"strconv"
 )
@@ -75,22 +70,12 @@ func resourceCache() *schema.Resource {
 func resourceCacheCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newCache := client.Cache{
 		EnvironmentName: d.Get("environment_name").(string),
 		Name:            d.Get("name").(string),
 	}
 	fillCache(&newCache, d)
-	err := json.NewEncoder(&buf).Encode(newCache)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CachePath, c.Organization, newCache.EnvironmentName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateCache(&newCache)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -142,22 +127,14 @@ func resourceCacheRead(ctx context.Context, d *schema.ResourceData, m interface{
 	var diags diag.Diagnostics
 	envName, name := client.CacheDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CachePathGet, c.Organization, envName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetCache(envName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Cache{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("environment_name", envName)
 	d.Set("name", name)
 	if retVal.Description != "" {
@@ -185,21 +162,12 @@ func resourceCacheUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	var diags diag.Diagnostics
 	envName, name := client.CacheDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upCache := client.Cache{
 		EnvironmentName: envName,
 		Name:            name,
 	}
 	fillCache(&upCache, d)
-	err := json.NewEncoder(&buf).Encode(upCache)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CachePathGet, c.Organization, envName, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateCache(&upCache)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -210,8 +178,7 @@ func resourceCacheDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	var diags diag.Diagnostics
 	envName, name := client.CacheDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CachePathGet, c.Organization, envName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteCache(envName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
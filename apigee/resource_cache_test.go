@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccCache(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_cache", testAccCachePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_cache" "test" {
+  environment_name      = "test"
+  name                  = "acctest"
+  description           = "Acceptance test"
+  expiry_timeout_in_sec = 300
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_cache.test", testAccCachePath),
+					resource.TestCheckResourceAttr("apigee_cache.test", "id", "test:acctest"),
+					resource.TestCheckResourceAttr("apigee_cache.test", "expiry_timeout_in_sec", "300"),
+				),
+			},
+			{
+				ResourceName:      "apigee_cache.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCachePath(rs *terraform.ResourceState) string {
+	envName, name := client.CacheDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.CachePathGet, testAccOrganization, envName, name)
+}
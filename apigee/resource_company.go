@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceCompany() *schema.Resource {
@@ -45,21 +40,11 @@ func resourceCompany() *schema.Resource {
 func resourceCompanyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newCompany := client.Company{
 		Name: d.Get("name").(string),
 	}
 	fillCompany(&newCompany, d)
-	err := json.NewEncoder(&buf).Encode(newCompany)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateCompany(&newCompany)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -88,22 +73,14 @@ func fillCompany(c *client.Company, d *schema.ResourceData) {
 func resourceCompanyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetCompany(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Company{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("name", d.Id())
 	d.Set("display_name", retVal.DisplayName)
 	atts := map[string]string{}
@@ -117,20 +94,11 @@ func resourceCompanyRead(ctx context.Context, d *schema.ResourceData, m interfac
 func resourceCompanyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upCompany := client.Company{
 		Name: d.Id(),
 	}
 	fillCompany(&upCompany, d)
-	err := json.NewEncoder(&buf).Encode(upCompany)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyPathGet, c.Organization, d.Id())
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateCompany(d.Id(), &upCompany)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -140,8 +108,7 @@ func resourceCompanyUpdate(ctx context.Context, d *schema.ResourceData, m interf
 func resourceCompanyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyPathGet, c.Organization, d.Id())
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteCompany(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceCompanyApp() *schema.Resource {
@@ -50,38 +45,21 @@ func resourceCompanyApp() *schema.Resource {
 func resourceCompanyAppCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newCompanyApp := client.App{
 		CompanyName: d.Get("company_name").(string),
 		Name:        d.Get("name").(string),
 	}
 	fillCompanyApp(&newCompanyApp, d)
-	err := json.NewEncoder(&buf).Encode(newCompanyApp)
+	retVal, err := c.CreateCompanyApp(&newCompanyApp)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
 	}
-	requestPath := fmt.Sprintf(client.CompanyAppPath, c.Organization, newCompanyApp.CompanyName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	body, err := c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	//Set id before decoding to get generated key for deletion
+	//Set id before deleting generated keys
 	d.SetId(newCompanyApp.CompanyAppEncodeId())
-	retVal := &client.App{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		//Don't clear id since app was created
-		return diag.FromErr(err)
-	}
 	//Delete generated keys so that user is in control of keys via Terraform
 	for _, key := range retVal.Credentials {
-		requestPath = fmt.Sprintf(client.CompanyAppPathGeneratedKey, c.Organization, newCompanyApp.CompanyName, newCompanyApp.Name, key.ConsumerKey)
-		_, err = c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err = c.DeleteCompanyAppGeneratedKey(newCompanyApp.CompanyName, newCompanyApp.Name, key.ConsumerKey)
 		if err != nil {
 			//Don't clear id since app was created
 			return diag.FromErr(err)
@@ -111,22 +89,14 @@ func resourceCompanyAppRead(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 	companyName, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyAppPathGet, c.Organization, companyName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetCompanyApp(companyName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.App{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("company_name", companyName)
 	d.Set("name", name)
 	d.Set("callback_url", retVal.CallbackURL)
@@ -142,21 +112,12 @@ func resourceCompanyAppUpdate(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	companyName, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upCompanyApp := client.App{
 		CompanyName: companyName,
 		Name:        name,
 	}
 	fillCompanyApp(&upCompanyApp, d)
-	err := json.NewEncoder(&buf).Encode(upCompanyApp)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyAppPathGet, c.Organization, companyName, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateCompanyApp(&upCompanyApp)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -167,8 +128,7 @@ func resourceCompanyAppDelete(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	companyName, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyAppPathGet, c.Organization, companyName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteCompanyApp(companyName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
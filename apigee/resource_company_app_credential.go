@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceCompanyAppCredential() *schema.Resource {
@@ -71,7 +66,6 @@ func resourceCompanyAppCredential() *schema.Resource {
 func resourceCompanyAppCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newCompanyAppCredential := client.AppCredentialModify{
 		CompanyName:    d.Get("company_name").(string),
 		AppName:        d.Get("company_app_name").(string),
@@ -79,43 +73,15 @@ func resourceCompanyAppCredentialCreate(ctx context.Context, d *schema.ResourceD
 		ConsumerSecret: d.Get("consumer_secret").(string),
 	}
 	fillCompanyAppCredential(&newCompanyAppCredential, d)
-	err := json.NewEncoder(&buf).Encode(newCompanyAppCredential)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyAppCredentialPathCreate, c.Organization, newCompanyAppCredential.CompanyName, newCompanyAppCredential.AppName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateCompanyAppCredential(&newCompanyAppCredential)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
 	}
 	//Set id before adding products
 	d.SetId(newCompanyAppCredential.CompanyAppCredentialEncodeId())
-	//Add any products or attributes with POST
-	requestPath = fmt.Sprintf(client.CompanyAppCredentialPathGet, c.Organization, newCompanyAppCredential.CompanyName, newCompanyAppCredential.AppName, newCompanyAppCredential.ConsumerKey)
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(newCompanyAppCredential)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	//Add any scopes with PUT
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(newCompanyAppCredential)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	//Add any products, attributes or scopes
+	err = c.UpdateCompanyAppCredential(&newCompanyAppCredential)
 	if err != nil {
 		//Don't clear id since credential was created
 		return diag.FromErr(err)
@@ -150,22 +116,14 @@ func resourceCompanyAppCredentialRead(ctx context.Context, d *schema.ResourceDat
 	var diags diag.Diagnostics
 	companyName, appName, key := client.AppCredentialDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyAppCredentialPathGet, c.Organization, companyName, appName, key)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetCompanyAppCredential(companyName, appName, key)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.AppCredential{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("company_name", companyName)
 	d.Set("company_app_name", appName)
 	d.Set("consumer_key", key)
@@ -199,14 +157,12 @@ func resourceCompanyAppCredentialUpdate(ctx context.Context, d *schema.ResourceD
 				continue
 			}
 			//Delete product
-			requestPath := fmt.Sprintf(client.CompanyAppCredentialPathProduct, c.Organization, companyName, appName, key, oldProd)
-			_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+			err := c.RemoveCompanyAppCredentialProduct(companyName, appName, key, oldProd)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	}
-	buf := bytes.Buffer{}
 	upCompanyAppCredential := client.AppCredentialModify{
 		CompanyName:    companyName,
 		AppName:        appName,
@@ -214,26 +170,7 @@ func resourceCompanyAppCredentialUpdate(ctx context.Context, d *schema.ResourceD
 		ConsumerSecret: d.Get("consumer_secret").(string),
 	}
 	fillCompanyAppCredential(&upCompanyAppCredential, d)
-	//Handle products and attributes with POST
-	err := json.NewEncoder(&buf).Encode(upCompanyAppCredential)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyAppCredentialPathGet, c.Organization, companyName, appName, key)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	//Handle scopes with PUT
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(upCompanyAppCredential)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateCompanyAppCredential(&upCompanyAppCredential)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -244,8 +181,7 @@ func resourceCompanyAppCredentialDelete(ctx context.Context, d *schema.ResourceD
 	var diags diag.Diagnostics
 	companyName, appName, key := client.AppCredentialDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyAppCredentialPathGet, c.Organization, companyName, appName, key)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteCompanyAppCredential(companyName, appName, key)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,61 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccCompanyAppCredential(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_company_app_credential", testAccCompanyAppCredentialPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_company" "test" {
+  name = "acctest"
+}
+
+resource "apigee_product" "test" {
+  name               = "acctest"
+  display_name       = "Acceptance Test"
+  auto_approval_type = true
+}
+
+resource "apigee_company_app" "test" {
+  company_name = apigee_company.test.name
+  name         = "acctest"
+}
+
+resource "apigee_company_app_credential" "test" {
+  company_name     = apigee_company.test.name
+  company_app_name = apigee_company_app.test.name
+  consumer_key     = "acctest-key"
+  consumer_secret  = "acctest-secret"
+  api_products     = [apigee_product.test.name]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_company_app_credential.test", testAccCompanyAppCredentialPath),
+					resource.TestCheckResourceAttr("apigee_company_app_credential.test", "id", "acctest:acctest:acctest-key"),
+					resource.TestCheckResourceAttr("apigee_company_app_credential.test", "api_products.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_company_app_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCompanyAppCredentialPath(rs *terraform.ResourceState) string {
+	companyName, appName, consumerKey := client.AppCredentialDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.CompanyAppCredentialPathGet, testAccOrganization, companyName, appName, consumerKey)
+}
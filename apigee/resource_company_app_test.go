@@ -0,0 +1,51 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccCompanyApp(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_company_app", testAccCompanyAppPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_company" "test" {
+  name = "acctest"
+}
+
+resource "apigee_company_app" "test" {
+  company_name = apigee_company.test.name
+  name         = "acctest"
+  callback_url = "https://example.com/callback"
+  attributes = {
+    team = "platform"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_company_app.test", testAccCompanyAppPath),
+					resource.TestCheckResourceAttr("apigee_company_app.test", "id", "acctest:acctest"),
+					resource.TestCheckResourceAttr("apigee_company_app.test", "attributes.team", "platform"),
+				),
+			},
+			{
+				ResourceName:      "apigee_company_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCompanyAppPath(rs *terraform.ResourceState) string {
+	companyName, name := client.AppDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.CompanyAppPathGet, testAccOrganization, companyName, name)
+}
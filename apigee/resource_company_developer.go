@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 )
 
@@ -46,27 +41,12 @@ func resourceCompanyDeveloper() *schema.Resource {
 func resourceCompanyDeveloperCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newCompanyDeveloper := client.CompanyDeveloper{
 		CompanyName:    d.Get("company_name").(string),
 		DeveloperEmail: d.Get("developer_email").(string),
 	}
 	fillCompanyDeveloper(&newCompanyDeveloper, d)
-	newCompanyDevelopers := client.CompanyDeveloperList{
-		Developers: []client.CompanyDeveloper{
-			newCompanyDeveloper,
-		},
-	}
-	err := json.NewEncoder(&buf).Encode(newCompanyDevelopers)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyDeveloperPath, c.Organization, newCompanyDeveloper.CompanyName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.SetCompanyDeveloper(&newCompanyDeveloper)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -87,26 +67,18 @@ func resourceCompanyDeveloperRead(ctx context.Context, d *schema.ResourceData, m
 	companyName, developerEmail := client.CompanyDeveloperDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Must read all developers for this company and search for match
-	requestPath := fmt.Sprintf(client.CompanyDeveloperPath, c.Organization, companyName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListCompanyDevelopers(companyName)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.CompanyDeveloperList{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	//Search for developer
 	var foundCompanyDeveloper *client.CompanyDeveloper
 	foundCompanyDeveloper = nil
-	for _, cd := range retVal.Developers {
+	for _, cd := range retVal {
 		if cd.DeveloperEmail == developerEmail {
 			foundCompanyDeveloper = &cd
 			break
@@ -128,26 +100,12 @@ func resourceCompanyDeveloperUpdate(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 	companyName, developerEmail := client.CompanyDeveloperDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upCompanyDeveloper := client.CompanyDeveloper{
 		CompanyName:    companyName,
 		DeveloperEmail: developerEmail,
 	}
 	fillCompanyDeveloper(&upCompanyDeveloper, d)
-	upCompanyDevelopers := client.CompanyDeveloperList{
-		Developers: []client.CompanyDeveloper{
-			upCompanyDeveloper,
-		},
-	}
-	err := json.NewEncoder(&buf).Encode(upCompanyDevelopers)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.CompanyDeveloperPath, c.Organization, companyName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.SetCompanyDeveloper(&upCompanyDeveloper)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -158,8 +116,7 @@ func resourceCompanyDeveloperDelete(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 	companyName, developerEmail := client.CompanyDeveloperDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.CompanyDeveloperPathGet, c.Organization, companyName, developerEmail)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteCompanyDeveloper(companyName, developerEmail)
 	if err != nil {
 		return diag.FromErr(err)
 	}
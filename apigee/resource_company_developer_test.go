@@ -0,0 +1,55 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccCompanyDeveloper(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_company_developer", testAccCompanyDeveloperPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_developer" "test" {
+  email      = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  user_name  = "acctest"
+}
+
+resource "apigee_company" "test" {
+  name = "acctest"
+}
+
+resource "apigee_company_developer" "test" {
+  company_name    = apigee_company.test.name
+  developer_email = apigee_developer.test.email
+  role_name       = "admin"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_company_developer.test", testAccCompanyDeveloperPath),
+					resource.TestCheckResourceAttr("apigee_company_developer.test", "id", "acctest:acctest@example.com"),
+					resource.TestCheckResourceAttr("apigee_company_developer.test", "role_name", "admin"),
+				),
+			},
+			{
+				ResourceName:      "apigee_company_developer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCompanyDeveloperPath(rs *terraform.ResourceState) string {
+	companyName, developerEmail := client.CompanyDeveloperDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.CompanyDeveloperPathGet, testAccOrganization, companyName, developerEmail)
+}
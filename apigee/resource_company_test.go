@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccCompany(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_company", testAccCompanyPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_company" "test" {
+  name         = "acctest"
+  display_name = "Acceptance Test"
+  attributes = {
+    tier = "gold"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_company.test", testAccCompanyPath),
+					resource.TestCheckResourceAttr("apigee_company.test", "id", "acctest"),
+					resource.TestCheckResourceAttr("apigee_company.test", "attributes.tier", "gold"),
+				),
+			},
+			{
+				ResourceName:      "apigee_company.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCompanyPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.CompanyPathGet, testAccOrganization, rs.Primary.ID)
+}
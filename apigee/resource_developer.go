@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 )
 
@@ -55,7 +50,6 @@ func resourceDeveloper() *schema.Resource {
 func resourceDeveloperCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newDeveloper := client.Developer{
 		Email:     d.Get("email").(string),
 		FirstName: d.Get("first_name").(string),
@@ -63,16 +57,7 @@ func resourceDeveloperCreate(ctx context.Context, d *schema.ResourceData, m inte
 		UserName:  d.Get("user_name").(string),
 	}
 	fillDeveloper(&newDeveloper, d)
-	err := json.NewEncoder(&buf).Encode(newDeveloper)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.DeveloperPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateDeveloper(&newDeveloper)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -97,22 +82,14 @@ func fillDeveloper(c *client.Developer, d *schema.ResourceData) {
 func resourceDeveloperRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetDeveloper(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Developer{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("email", d.Id())
 	d.Set("first_name", retVal.FirstName)
 	d.Set("last_name", retVal.LastName)
@@ -128,7 +105,6 @@ func resourceDeveloperRead(ctx context.Context, d *schema.ResourceData, m interf
 func resourceDeveloperUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	//Do not use id since that can change on update
 	upDeveloper := client.Developer{
 		Email:     d.Get("email").(string),
@@ -137,15 +113,7 @@ func resourceDeveloperUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		UserName:  d.Get("user_name").(string),
 	}
 	fillDeveloper(&upDeveloper, d)
-	err := json.NewEncoder(&buf).Encode(upDeveloper)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.DeveloperPathGet, c.Organization, d.Id())
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateDeveloper(d.Id(), &upDeveloper)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -157,8 +125,7 @@ func resourceDeveloperUpdate(ctx context.Context, d *schema.ResourceData, m inte
 func resourceDeveloperDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperPathGet, c.Organization, d.Id())
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteDeveloper(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
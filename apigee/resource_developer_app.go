@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 )
 
@@ -53,38 +48,21 @@ func resourceDeveloperApp() *schema.Resource {
 func resourceDeveloperAppCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newDeveloperApp := client.App{
 		DeveloperEmail: d.Get("developer_email").(string),
 		Name:           d.Get("name").(string),
 	}
 	fillDeveloperApp(&newDeveloperApp, d)
-	err := json.NewEncoder(&buf).Encode(newDeveloperApp)
+	retVal, err := c.CreateDeveloperApp(&newDeveloperApp)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
 	}
-	requestPath := fmt.Sprintf(client.DeveloperAppPath, c.Organization, newDeveloperApp.DeveloperEmail)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	body, err := c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	//Set id before decoding to get generated key for deletion
+	//Set id before deleting generated keys
 	d.SetId(newDeveloperApp.DeveloperAppEncodeId())
-	retVal := &client.App{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		//Don't clear id since app was created
-		return diag.FromErr(err)
-	}
 	//Delete generated keys so that user is in control of keys via Terraform
 	for _, key := range retVal.Credentials {
-		requestPath = fmt.Sprintf(client.DeveloperAppPathGeneratedKey, c.Organization, newDeveloperApp.DeveloperEmail, newDeveloperApp.Name, key.ConsumerKey)
-		_, err = c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err = c.DeleteDeveloperAppGeneratedKey(newDeveloperApp.DeveloperEmail, newDeveloperApp.Name, key.ConsumerKey)
 		if err != nil {
 			//Don't clear id since app was created
 			return diag.FromErr(err)
@@ -114,22 +92,14 @@ func resourceDeveloperAppRead(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	developerEmail, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperAppPathGet, c.Organization, developerEmail, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetDeveloperApp(developerEmail, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.App{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("developer_email", developerEmail)
 	d.Set("name", name)
 	d.Set("callback_url", retVal.CallbackURL)
@@ -145,21 +115,12 @@ func resourceDeveloperAppUpdate(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	developerEmail, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upDeveloperApp := client.App{
 		DeveloperEmail: developerEmail,
 		Name:           name,
 	}
 	fillDeveloperApp(&upDeveloperApp, d)
-	err := json.NewEncoder(&buf).Encode(upDeveloperApp)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.DeveloperAppPathGet, c.Organization, developerEmail, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateDeveloperApp(&upDeveloperApp)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -170,8 +131,7 @@ func resourceDeveloperAppDelete(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	developerEmail, name := client.AppDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperAppPathGet, c.Organization, developerEmail, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteDeveloperApp(developerEmail, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
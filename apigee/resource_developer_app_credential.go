@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"regexp"
 )
 
@@ -74,7 +69,6 @@ func resourceDeveloperAppCredential() *schema.Resource {
 func resourceDeveloperAppCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newDeveloperAppCredential := client.AppCredentialModify{
 		DeveloperEmail: d.Get("developer_email").(string),
 		AppName:        d.Get("developer_app_name").(string),
@@ -82,43 +76,15 @@ func resourceDeveloperAppCredentialCreate(ctx context.Context, d *schema.Resourc
 		ConsumerSecret: d.Get("consumer_secret").(string),
 	}
 	fillDeveloperAppCredential(&newDeveloperAppCredential, d)
-	err := json.NewEncoder(&buf).Encode(newDeveloperAppCredential)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.DeveloperAppCredentialPathCreate, c.Organization, newDeveloperAppCredential.DeveloperEmail, newDeveloperAppCredential.AppName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateDeveloperAppCredential(&newDeveloperAppCredential)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
 	}
 	//Set id before adding products
 	d.SetId(newDeveloperAppCredential.DeveloperAppCredentialEncodeId())
-	//Add any products or attributes with POST
-	requestPath = fmt.Sprintf(client.DeveloperAppCredentialPathGet, c.Organization, newDeveloperAppCredential.DeveloperEmail, newDeveloperAppCredential.AppName, newDeveloperAppCredential.ConsumerKey)
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(newDeveloperAppCredential)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	//Add any scopes with PUT
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(newDeveloperAppCredential)
-	if err != nil {
-		//Don't clear id since credential was created
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	//Add any products, attributes or scopes
+	err = c.UpdateDeveloperAppCredential(&newDeveloperAppCredential)
 	if err != nil {
 		//Don't clear id since credential was created
 		return diag.FromErr(err)
@@ -153,22 +119,14 @@ func resourceDeveloperAppCredentialRead(ctx context.Context, d *schema.ResourceD
 	var diags diag.Diagnostics
 	developerEmail, appName, key := client.AppCredentialDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperAppCredentialPathGet, c.Organization, developerEmail, appName, key)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetDeveloperAppCredential(developerEmail, appName, key)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.AppCredential{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("developer_email", developerEmail)
 	d.Set("developer_app_name", appName)
 	d.Set("consumer_key", key)
@@ -202,14 +160,12 @@ func resourceDeveloperAppCredentialUpdate(ctx context.Context, d *schema.Resourc
 				continue
 			}
 			//Delete product
-			requestPath := fmt.Sprintf(client.DeveloperAppCredentialPathProduct, c.Organization, developerEmail, appName, key, oldProd)
-			_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+			err := c.RemoveDeveloperAppCredentialProduct(developerEmail, appName, key, oldProd)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	}
-	buf := bytes.Buffer{}
 	upDeveloperAppCredential := client.AppCredentialModify{
 		DeveloperEmail: developerEmail,
 		AppName:        appName,
@@ -217,26 +173,7 @@ func resourceDeveloperAppCredentialUpdate(ctx context.Context, d *schema.Resourc
 		ConsumerSecret: d.Get("consumer_secret").(string),
 	}
 	fillDeveloperAppCredential(&upDeveloperAppCredential, d)
-	//Handle products and attributes with POST
-	err := json.NewEncoder(&buf).Encode(upDeveloperAppCredential)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.DeveloperAppCredentialPathGet, c.Organization, developerEmail, appName, key)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	//Handle scopes with PUT
-	buf = bytes.Buffer{}
-	err = json.NewEncoder(&buf).Encode(upDeveloperAppCredential)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateDeveloperAppCredential(&upDeveloperAppCredential)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -247,8 +184,7 @@ func resourceDeveloperAppCredentialDelete(ctx context.Context, d *schema.Resourc
 	var diags diag.Diagnostics
 	developerEmail, appName, key := client.AppCredentialDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.DeveloperAppCredentialPathGet, c.Organization, developerEmail, appName, key)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteDeveloperAppCredential(developerEmail, appName, key)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,69 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccDeveloperAppCredential(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_developer_app_credential", testAccDeveloperAppCredentialPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_developer" "test" {
+  email      = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  user_name  = "acctest"
+}
+
+resource "apigee_product" "test" {
+  name               = "acctest"
+  display_name       = "Acceptance Test"
+  auto_approval_type = true
+}
+
+resource "apigee_developer_app" "test" {
+  developer_email = apigee_developer.test.email
+  name            = "acctest"
+}
+
+resource "apigee_developer_app_credential" "test" {
+  developer_email    = apigee_developer.test.email
+  developer_app_name = apigee_developer_app.test.name
+  consumer_key       = "acctest-key"
+  consumer_secret    = "acctest-secret"
+  api_products       = [apigee_product.test.name]
+  scopes             = ["read"]
+  attributes = {
+    team = "platform"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_developer_app_credential.test", testAccDeveloperAppCredentialPath),
+					resource.TestCheckResourceAttr("apigee_developer_app_credential.test", "id", "acctest@example.com:acctest:acctest-key"),
+					resource.TestCheckResourceAttr("apigee_developer_app_credential.test", "api_products.#", "1"),
+					resource.TestCheckResourceAttr("apigee_developer_app_credential.test", "scopes.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_developer_app_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDeveloperAppCredentialPath(rs *terraform.ResourceState) string {
+	developerEmail, appName, consumerKey := client.AppCredentialDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.DeveloperAppCredentialPathGet, testAccOrganization, developerEmail, appName, consumerKey)
+}
@@ -0,0 +1,54 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccDeveloperApp(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_developer_app", testAccDeveloperAppPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_developer" "test" {
+  email      = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  user_name  = "acctest"
+}
+
+resource "apigee_developer_app" "test" {
+  developer_email = apigee_developer.test.email
+  name            = "acctest"
+  callback_url    = "https://example.com/callback"
+  attributes = {
+    team = "platform"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_developer_app.test", testAccDeveloperAppPath),
+					resource.TestCheckResourceAttr("apigee_developer_app.test", "id", "acctest@example.com:acctest"),
+					resource.TestCheckResourceAttr("apigee_developer_app.test", "callback_url", "https://example.com/callback"),
+				),
+			},
+			{
+				ResourceName:      "apigee_developer_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDeveloperAppPath(rs *terraform.ResourceState) string {
+	developerEmail, name := client.AppDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.DeveloperAppPathGet, testAccOrganization, developerEmail, name)
+}
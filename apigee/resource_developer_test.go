@@ -0,0 +1,47 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccDeveloper(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_developer", testAccDeveloperPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_developer" "test" {
+  email      = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  user_name  = "acctest"
+  attributes = {
+    team = "platform"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_developer.test", testAccDeveloperPath),
+					resource.TestCheckResourceAttr("apigee_developer.test", "id", "acctest@example.com"),
+					resource.TestCheckResourceAttr("apigee_developer.test", "attributes.team", "platform"),
+				),
+			},
+			{
+				ResourceName:      "apigee_developer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDeveloperPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.DeveloperPathGet, testAccOrganization, rs.Primary.ID)
+}
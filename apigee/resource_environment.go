@@ -0,0 +1,261 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"strconv"
+)
+
+func resourceEnvironment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentCreate,
+		ReadContext:   resourceEnvironmentRead,
+		UpdateContext: resourceEnvironmentUpdate,
+		DeleteContext: resourceEnvironmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultOperationTimeout),
+			Update: schema.DefaultTimeout(DefaultOperationTimeout),
+			Delete: schema.DefaultTimeout(DefaultOperationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deployment_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PROXY", "ARCHIVE"}, false),
+			},
+			"api_proxy_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PROGRAMMABLE", "CONFIGURABLE"}, false),
+			},
+			"node_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_node_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_node_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"current_aggregate_node_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fillEnvironment(c *client.Environment, d *schema.ResourceData) {
+	c.DisplayName = d.Get("display_name").(string)
+	c.Description = d.Get("description").(string)
+	p, ok := d.GetOk("properties")
+	if ok {
+		c.Properties = &client.EnvironmentProperties{}
+		properties := p.(map[string]interface{})
+		for name, value := range properties {
+			c.Properties.Properties = append(c.Properties.Properties, client.Attribute{
+				Name:  name,
+				Value: value.(string),
+			})
+		}
+	}
+	c.DeploymentType = d.Get("deployment_type").(string)
+	c.ApiProxyType = d.Get("api_proxy_type").(string)
+	nodeConfigs := d.Get("node_config").([]interface{})
+	if (len(nodeConfigs) > 0) && (nodeConfigs[0] != nil) {
+		nodeConfig := nodeConfigs[0].(map[string]interface{})
+		c.NodeConfig = &client.EnvironmentNodeConfig{}
+		if nodeConfig["min_node_count"].(int) > 0 {
+			c.NodeConfig.MinNodeCount = strconv.Itoa(nodeConfig["min_node_count"].(int))
+		}
+		if nodeConfig["max_node_count"].(int) > 0 {
+			c.NodeConfig.MaxNodeCount = strconv.Itoa(nodeConfig["max_node_count"].(int))
+		}
+	}
+}
+
+func checkGoogleEnvironmentArgs(c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	if c.IsGoogle() {
+		return nil
+	}
+	for _, key := range []string{"deployment_type", "api_proxy_type", "node_config"} {
+		if _, ok := d.GetOk(key); ok {
+			return diag.Errorf("%s cannot be set for non-Google Cloud Apigee versions", key)
+		}
+	}
+	return nil
+}
+
+func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	diags = checkGoogleEnvironmentArgs(c, d)
+	if diags.HasError() {
+		return diags
+	}
+	newEnvironment := client.Environment{
+		Name: d.Get("name").(string),
+	}
+	fillEnvironment(&newEnvironment, d)
+	op, err := c.CreateEnvironment(&newEnvironment)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newEnvironment.Name)
+	//Wait until the environment is ready, otherwise anything deployed to it right away will fail
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	retVal, err := c.GetEnvironment(d.Id())
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	d.Set("name", d.Id())
+	d.Set("display_name", retVal.DisplayName)
+	d.Set("description", retVal.Description)
+	//Apigee adds its own properties to new environments, so only track the configured ones
+	configured := d.Get("properties").(map[string]interface{})
+	properties := map[string]string{}
+	if retVal.Properties != nil {
+		for _, property := range retVal.Properties.Properties {
+			if _, ok := configured[property.Name]; ok {
+				properties[property.Name] = property.Value
+			}
+		}
+	}
+	d.Set("properties", properties)
+	d.Set("deployment_type", retVal.DeploymentType)
+	d.Set("api_proxy_type", retVal.ApiProxyType)
+	if retVal.NodeConfig != nil {
+		minNodeCount, _ := strconv.Atoi(retVal.NodeConfig.MinNodeCount)
+		maxNodeCount, _ := strconv.Atoi(retVal.NodeConfig.MaxNodeCount)
+		currentAggregateNodeCount, _ := strconv.Atoi(retVal.NodeConfig.CurrentAggregateNodeCount)
+		d.Set("node_config", []interface{}{
+			map[string]interface{}{
+				"min_node_count":               minNodeCount,
+				"max_node_count":               maxNodeCount,
+				"current_aggregate_node_count": currentAggregateNodeCount,
+			},
+		})
+	} else {
+		d.Set("node_config", nil)
+	}
+	return diags
+}
+
+func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	diags := checkGoogleEnvironmentArgs(c, d)
+	if diags.HasError() {
+		return diags
+	}
+	upEnvironment := client.Environment{
+		Name: d.Id(),
+	}
+	fillEnvironment(&upEnvironment, d)
+	//The PUT replaces every property, so keep the ones Apigee added that Read does not track
+	current, err := c.GetEnvironment(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	mergeEnvironmentProperties(&upEnvironment, current, d)
+	op, err := c.UpdateEnvironment(d.Id(), &upEnvironment)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func mergeEnvironmentProperties(upEnvironment *client.Environment, current *client.Environment, d *schema.ResourceData) {
+	if (current.Properties == nil) || (len(current.Properties.Properties) == 0) {
+		return
+	}
+	//Properties removed from the configuration are dropped, everything else not configured is left alone
+	o, n := d.GetChange("properties")
+	previous := o.(map[string]interface{})
+	configured := n.(map[string]interface{})
+	if upEnvironment.Properties == nil {
+		upEnvironment.Properties = &client.EnvironmentProperties{}
+	}
+	for _, property := range current.Properties.Properties {
+		if _, ok := configured[property.Name]; ok {
+			continue
+		}
+		if _, ok := previous[property.Name]; ok {
+			continue
+		}
+		upEnvironment.Properties.Properties = append(upEnvironment.Properties.Properties, property)
+	}
+}
+
+func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	op, err := c.DeleteEnvironment(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,107 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceEnvironmentGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentGroupCreate,
+		ReadContext:   resourceEnvironmentGroupRead,
+		UpdateContext: resourceEnvironmentGroupUpdate,
+		DeleteContext: resourceEnvironmentGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultOperationTimeout),
+			Update: schema.DefaultTimeout(DefaultOperationTimeout),
+			Delete: schema.DefaultTimeout(DefaultOperationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"hostnames": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceEnvironmentGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	if !c.IsGoogle() {
+		return diag.Errorf("apigee_environment_group is only supported for Google Cloud Apigee versions, use apigee_virtual_host instead")
+	}
+	newEnvironmentGroup := client.EnvironmentGroup{
+		Name:      d.Get("name").(string),
+		Hostnames: convertSetToArray(d.Get("hostnames").(*schema.Set)),
+	}
+	op, err := c.CreateEnvironmentGroup(&newEnvironmentGroup)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newEnvironmentGroup.Name)
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceEnvironmentGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	retVal, err := c.GetEnvironmentGroup(d.Id())
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	d.Set("name", d.Id())
+	d.Set("hostnames", retVal.Hostnames)
+	return diags
+}
+
+func resourceEnvironmentGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	op, err := c.UpdateEnvironmentGroupHostnames(d.Id(), convertSetToArray(d.Get("hostnames").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceEnvironmentGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	op, err := c.DeleteEnvironmentGroup(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,116 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceEnvironmentGroupAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentGroupAttachmentCreate,
+		ReadContext:   resourceEnvironmentGroupAttachmentRead,
+		DeleteContext: resourceEnvironmentGroupAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultOperationTimeout),
+			Delete: schema.DefaultTimeout(DefaultOperationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"environment_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func findEnvironmentGroupAttachment(c *client.Client, groupName string, envName string) (*client.EnvironmentGroupAttachment, error) {
+	//Attachment names are generated by Google so look the attachment up by environment
+	attachments, err := c.ListEnvironmentGroupAttachments(groupName)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		if attachment.EnvironmentName == envName {
+			attachment.EnvironmentGroupName = groupName
+			return &attachment, nil
+		}
+	}
+	return nil, nil
+}
+
+func resourceEnvironmentGroupAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	if !c.IsGoogle() {
+		return diag.Errorf("apigee_environment_group_attachment is only supported for Google Cloud Apigee versions")
+	}
+	newAttachment := client.EnvironmentGroupAttachment{
+		EnvironmentGroupName: d.Get("environment_group_name").(string),
+		EnvironmentName:      d.Get("environment_name").(string),
+	}
+	op, err := c.CreateEnvironmentGroupAttachment(&newAttachment)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newAttachment.EnvironmentGroupAttachmentEncodeId())
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceEnvironmentGroupAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	groupName, envName := client.EnvironmentGroupAttachmentDecodeId(d.Id())
+	c := m.(*client.Client)
+	retVal, err := findEnvironmentGroupAttachment(c, groupName, envName)
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	if retVal == nil {
+		d.SetId("")
+		return diags
+	}
+	d.Set("environment_group_name", groupName)
+	d.Set("environment_name", envName)
+	return diags
+}
+
+func resourceEnvironmentGroupAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	groupName, envName := client.EnvironmentGroupAttachmentDecodeId(d.Id())
+	c := m.(*client.Client)
+	retVal, err := findEnvironmentGroupAttachment(c, groupName, envName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if retVal != nil {
+		op, err := c.DeleteEnvironmentGroupAttachment(groupName, retVal.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,46 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccEnvironmentGroupAttachment(t *testing.T) {
+	fake := newFakeApigee(t, true)
+	path := func(rs *terraform.ResourceState) string {
+		envGroupName, envName := client.EnvironmentGroupAttachmentDecodeId(rs.Primary.ID)
+		return fake.attachmentPath(fmt.Sprintf(client.EnvironmentGroupAttachmentPath, testAccOrganization, envGroupName), envName)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_environment_group_attachment", path),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_environment_group" "test" {
+  name      = "acctest"
+  hostnames = ["api.example.com"]
+}
+
+resource "apigee_environment_group_attachment" "test" {
+  environment_group_name = apigee_environment_group.test.name
+  environment_name       = "test"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_environment_group_attachment.test", path),
+					resource.TestCheckResourceAttr("apigee_environment_group_attachment.test", "id", "acctest:test"),
+				),
+			},
+			{
+				ResourceName:      "apigee_environment_group_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
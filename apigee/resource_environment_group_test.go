@@ -0,0 +1,43 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccEnvironmentGroup(t *testing.T) {
+	//Environment groups only exist on Google
+	fake := newFakeApigee(t, true)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_environment_group", testAccEnvironmentGroupPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_environment_group" "test" {
+  name      = "acctest"
+  hostnames = ["api.example.com"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_environment_group.test", testAccEnvironmentGroupPath),
+					resource.TestCheckResourceAttr("apigee_environment_group.test", "id", "acctest"),
+					resource.TestCheckResourceAttr("apigee_environment_group.test", "hostnames.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_environment_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccEnvironmentGroupPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.EnvironmentGroupPathGet, testAccOrganization, rs.Primary.ID)
+}
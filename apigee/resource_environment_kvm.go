@@ -1,13 +1,8 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
@@ -62,22 +57,12 @@ func resourceEnvironmentKVM() *schema.Resource {
 func resourceEnvironmentKVMCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newEnvironmentKVM := client.KVM{
 		EnvironmentName: d.Get("environment_name").(string),
 		Name:            d.Get("name").(string),
 	}
 	fillEnvironmentKVM(&newEnvironmentKVM, c.IsPublic(), d)
-	err := json.NewEncoder(&buf).Encode(newEnvironmentKVM)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.EnvironmentKVMPath, c.Organization, newEnvironmentKVM.EnvironmentName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateEnvironmentKVM(&newEnvironmentKVM)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -90,19 +75,13 @@ func resourceEnvironmentKVMCreate(_ context.Context, d *schema.ResourceData, m i
 		if ok {
 			entries := e.(map[string]interface{})
 			for name, value := range entries {
-				buf = bytes.Buffer{}
-				err = json.NewEncoder(&buf).Encode(client.Attribute{
+				err = c.CreateEnvironmentKVMEntry(newEnvironmentKVM.EnvironmentName, newEnvironmentKVM.Name, &client.Attribute{
 					Name:  name,
 					Value: value.(string),
 				})
 				if err != nil {
 					return diag.FromErr(err)
 				}
-				requestPath = fmt.Sprintf(client.EnvironmentKVMPathEntries, c.Organization, newEnvironmentKVM.EnvironmentName, newEnvironmentKVM.Name)
-				_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
-				if err != nil {
-					return diag.FromErr(err)
-				}
 
 				newEnvironmentKVM.Entries = append(newEnvironmentKVM.Entries, client.Attribute{
 					Name:  name,
@@ -145,22 +124,14 @@ func resourceEnvironmentKVMRead(_ context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	envName, name := client.KVMDecodeId(d.Id())
 
-	requestPath := fmt.Sprintf(client.EnvironmentKVMPathGet, c.Organization, envName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetEnvironmentKVM(envName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.KVM{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("environment_name", envName)
 	d.Set("name", name)
 	d.Set("encrypted", retVal.Encrypted)
@@ -181,23 +152,15 @@ func resourceEnvironmentKVMReadPublic(d *schema.ResourceData, m interface{}) dia
 	envName, name := client.KVMDecodeId(d.Id())
 	c := m.(*client.Client)
 
-	requestPath := fmt.Sprintf(client.EnvironmentKVMPath, c.Organization, envName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListEnvironmentKVMs(envName)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
 
-	var retVal []string
-	err = json.NewDecoder(body).Decode(&retVal)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	// Find the specified KVM in the list, no get by ID method exists on public:
 	// https://cloud.google.com/apigee/docs/reference/apis/apigee/rest/v1/organizations.environments.keyvaluemaps/list
 	found := false
@@ -208,21 +171,14 @@ func resourceEnvironmentKVMReadPublic(d *schema.ResourceData, m interface{}) dia
 			d.Set("name", name)
 			d.Set("encrypted", true) // All Apigee cloud offerings are encrypted now
 
-			// Retrieve individual KV's
-			requestPath = fmt.Sprintf(client.EnvironmentKVMPathEntries, c.Organization, envName, name)
-			body, err = c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			var res client.KVMEntries
-			err = json.NewDecoder(body).Decode(&res)
+			// Retrieve individual KV's, following every page
+			res, err := c.ListEnvironmentKVMEntries(envName, name)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 
 			entries := map[string]string{}
-			for _, e := range res.KeyValueEntries {
+			for _, e := range res {
 				entries[e.Name] = e.Value
 			}
 			d.Set("sensitive_entry", entries)
@@ -259,15 +215,11 @@ func resourceEnvironmentKVMUpdate(_ context.Context, d *schema.ResourceData, m i
 			continue
 		}
 		//Delete entry
-		requestPath := fmt.Sprintf(client.EnvironmentKVMPathEntriesGet, c.Organization, envName, name, oldKey)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err := c.DeleteEnvironmentKVMEntry(envName, name, oldKey)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
 	//Public Apigee requires entries to be added/changed individually
 	if c.IsPublic() {
 		//Check for addition/modification of entries
@@ -281,41 +233,29 @@ func resourceEnvironmentKVMUpdate(_ context.Context, d *schema.ResourceData, m i
 					continue
 				}
 			}
-			buf := bytes.Buffer{}
 			newOrModEntry := client.Attribute{
 				Name:  newKey,
 				Value: newOrModValue,
 			}
-			err := json.NewEncoder(&buf).Encode(newOrModEntry)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			requestPath := ""
+			var err error
 			if oldHasKey {
 				//Change entry
-				requestPath = fmt.Sprintf(client.EnvironmentKVMPathEntriesGet, c.Organization, envName, name, newKey)
+				err = c.UpdateEnvironmentKVMEntry(envName, name, &newOrModEntry)
 			} else {
 				//Add entry
-				requestPath = fmt.Sprintf(client.EnvironmentKVMPathEntries, c.Organization, envName, name)
+				err = c.CreateEnvironmentKVMEntry(envName, name, &newOrModEntry)
 			}
-			_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	} else {
-		buf := bytes.Buffer{}
 		upEnvironmentKVM := client.KVM{
 			EnvironmentName: envName,
 			Name:            name,
 		}
 		fillEnvironmentKVM(&upEnvironmentKVM, c.IsPublic(), d)
-		err := json.NewEncoder(&buf).Encode(upEnvironmentKVM)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		requestPath := fmt.Sprintf(client.EnvironmentKVMPathGet, c.Organization, envName, name)
-		_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+		err := c.UpdateEnvironmentKVM(&upEnvironmentKVM)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -327,8 +267,7 @@ func resourceEnvironmentKVMDelete(_ context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	envName, name := client.KVMDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.EnvironmentKVMPathGet, c.Organization, envName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteEnvironmentKVM(envName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,57 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccEnvironmentKVM(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		//Google only has encrypted maps, whose values are never returned
+		entries := `
+  entry = {
+    key1 = "value1"
+    key2 = "value2"
+  }`
+		if fake.google {
+			entries = `
+  encrypted = true
+  sensitive_entry = {
+    key1 = "value1"
+    key2 = "value2"
+  }`
+		}
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_environment_kvm", testAccEnvironmentKVMPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + `
+resource "apigee_environment_kvm" "test" {
+  environment_name = "test"
+  name             = "acctest"` + entries + `
+}
+`,
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_environment_kvm.test", testAccEnvironmentKVMPath),
+						resource.TestCheckResourceAttr("apigee_environment_kvm.test", "id", "test:acctest"),
+					),
+				},
+				{
+					ResourceName:      "apigee_environment_kvm.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
+func testAccEnvironmentKVMPath(rs *terraform.ResourceState) string {
+	envName, name := client.KVMDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.EnvironmentKVMPathGet, testAccOrganization, envName, name)
+}
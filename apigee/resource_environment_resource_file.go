@@ -1,16 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 )
 
 func resourceEnvironmentResourceFile() *schema.Resource {
@@ -58,24 +52,7 @@ func resourceEnvironmentResourceFileCreate(ctx context.Context, d *schema.Resour
 		Type:            d.Get("type").(string),
 		Name:            d.Get("name").(string),
 	}
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.EnvironmentResourceFilePath, c.Organization, newEnvironmentResourceFile.EnvironmentName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
-	}
-	requestQuery := url.Values{
-		"type": []string{newEnvironmentResourceFile.Type},
-		"name": []string{newEnvironmentResourceFile.Name},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err := c.CreateEnvironmentResourceFile(&newEnvironmentResourceFile, d.Get("file").(string))
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -89,25 +66,17 @@ func resourceEnvironmentResourceFileRead(ctx context.Context, d *schema.Resource
 	envName, rtype, name := client.EnvironmentResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Reading specific file returns actual contents of file so read all files of type and search for name instead
-	requestPath := fmt.Sprintf(client.EnvironmentResourceFilePathOfType, c.Organization, envName, rtype)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListEnvironmentResourceFilesOfType(envName, rtype)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.ResourceFilesOfType{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	//Look for existence of name
 	found := false
-	for _, file := range retVal.Files {
+	for _, file := range retVal {
 		if file.Name == name {
 			found = true
 			break
@@ -127,19 +96,12 @@ func resourceEnvironmentResourceFileUpdate(ctx context.Context, d *schema.Resour
 	var diags diag.Diagnostics
 	envName, rtype, name := client.EnvironmentResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.EnvironmentResourceFilePathGet, c.Organization, envName, rtype, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
+	upEnvironmentResourceFile := client.ResourceFile{
+		EnvironmentName: envName,
+		Type:            rtype,
+		Name:            name,
 	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, buf)
+	err := c.UpdateEnvironmentResourceFile(&upEnvironmentResourceFile, d.Get("file").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -150,8 +112,7 @@ func resourceEnvironmentResourceFileDelete(ctx context.Context, d *schema.Resour
 	var diags diag.Diagnostics
 	envName, rtype, name := client.EnvironmentResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.EnvironmentResourceFilePathGet, c.Organization, envName, rtype, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteEnvironmentResourceFile(envName, rtype, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,46 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccEnvironmentResourceFile(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_environment_resource_file", testAccEnvironmentResourceFilePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_environment_resource_file" "test" {
+  environment_name = "test"
+  type             = "jsc"
+  name             = "acctest.js"
+  file             = %q
+  file_hash        = "1"
+}
+`, testAccFixture(t, "resource.js")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_environment_resource_file.test", testAccEnvironmentResourceFilePath),
+					resource.TestCheckResourceAttr("apigee_environment_resource_file.test", "id", "test:jsc:acctest.js"),
+				),
+			},
+			{
+				ResourceName:            "apigee_environment_resource_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccEnvironmentResourceFilePath(rs *terraform.ResourceState) string {
+	envName, rtype, name := client.EnvironmentResourceFileDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.EnvironmentResourceFilePathGet, testAccOrganization, envName, rtype, name)
+}
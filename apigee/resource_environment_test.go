@@ -0,0 +1,77 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccEnvironment(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		steps := []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + testAccEnvironmentConfig("Acceptance Test", "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_environment.test", testAccEnvironmentPath),
+					resource.TestCheckResourceAttr("apigee_environment.test", "id", "acctest"),
+					resource.TestCheckResourceAttr("apigee_environment.test", "properties.feature", "v1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_environment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				//Read only tracks the properties that are configured
+				ImportStateVerifyIgnore: []string{"properties"},
+			},
+		}
+		if fake.google {
+			//Google adds properties of its own, which an update must not drop
+			steps = append(steps, resource.TestStep{
+				Config: testAccProviderConfig(fake) + testAccEnvironmentConfig("Updated", "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("apigee_environment.test", "display_name", "Updated"),
+					resource.TestCheckResourceAttr("apigee_environment.test", "properties.feature", "v2"),
+					testAccCheckEnvironmentProperty(fake, "acctest", fakeServerManagedProperty),
+				),
+			})
+		}
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_environment", testAccEnvironmentPath),
+			Steps:             steps,
+		})
+	})
+}
+
+func testAccEnvironmentConfig(displayName string, feature string) string {
+	return fmt.Sprintf(`
+resource "apigee_environment" "test" {
+  name         = "acctest"
+  display_name = %q
+  description  = "Acceptance test"
+  properties = {
+    feature = %q
+  }
+}
+`, displayName, feature)
+}
+
+func testAccEnvironmentPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.EnvironmentPathGet, testAccOrganization, rs.Primary.ID)
+}
+
+func testAccCheckEnvironmentProperty(fake *fakeApigee, name string, property string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		env := fake.get(fmt.Sprintf(client.EnvironmentPathGet, testAccOrganization, name))
+		properties, _ := env["properties"].(map[string]interface{})
+		list, _ := properties["property"].([]interface{})
+		if findEntry(list, property) < 0 {
+			return fmt.Errorf("property %s was dropped from environment %s", property, name)
+		}
+		return nil
+	}
+}
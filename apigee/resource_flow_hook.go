@@ -0,0 +1,133 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceFlowHook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFlowHookCreate,
+		ReadContext:   resourceFlowHookRead,
+		UpdateContext: resourceFlowHookUpdate,
+		DeleteContext: resourceFlowHookDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"environment_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"flow_hook_point": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(client.FlowHookPoints, false),
+			},
+			"shared_flow_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"continue_on_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func fillFlowHook(c *client.FlowHook, d *schema.ResourceData) {
+	c.SharedFlowName = d.Get("shared_flow_name").(string)
+	c.ContinueOnError = d.Get("continue_on_error").(bool)
+	c.Description = d.Get("description").(string)
+}
+
+func resourceFlowHookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	if d.Get("description").(string) != "" {
+		if !c.IsGoogle() {
+			return diag.Errorf("description cannot be set for non-Google Cloud Apigee versions")
+		}
+	}
+	newFlowHook := client.FlowHook{
+		EnvironmentName: d.Get("environment_name").(string),
+		FlowHookPoint:   d.Get("flow_hook_point").(string),
+	}
+	fillFlowHook(&newFlowHook, d)
+	err := c.AttachFlowHook(&newFlowHook)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newFlowHook.FlowHookEncodeId())
+	return diags
+}
+
+func resourceFlowHookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	envName, flowHookPoint := client.FlowHookDecodeId(d.Id())
+	c := m.(*client.Client)
+	retVal, err := c.GetFlowHook(envName, flowHookPoint)
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	//Flow hook points always exist, so a detached flow hook comes back without a shared flow
+	if retVal.SharedFlowName == "" {
+		d.SetId("")
+		return diags
+	}
+	d.Set("environment_name", envName)
+	d.Set("flow_hook_point", flowHookPoint)
+	d.Set("shared_flow_name", retVal.SharedFlowName)
+	d.Set("continue_on_error", retVal.ContinueOnError)
+	d.Set("description", retVal.Description)
+	return diags
+}
+
+func resourceFlowHookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	envName, flowHookPoint := client.FlowHookDecodeId(d.Id())
+	c := m.(*client.Client)
+	if d.Get("description").(string) != "" {
+		if !c.IsGoogle() {
+			return diag.Errorf("description cannot be set for non-Google Cloud Apigee versions")
+		}
+	}
+	upFlowHook := client.FlowHook{
+		EnvironmentName: envName,
+		FlowHookPoint:   flowHookPoint,
+	}
+	fillFlowHook(&upFlowHook, d)
+	err := c.AttachFlowHook(&upFlowHook)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceFlowHookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	envName, flowHookPoint := client.FlowHookDecodeId(d.Id())
+	c := m.(*client.Client)
+	err := c.DetachFlowHook(envName, flowHookPoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,50 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccFlowHook(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_flow_hook", testAccFlowHookPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_shared_flow" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_flow_hook" "test" {
+  environment_name = "test"
+  flow_hook_point  = "PreProxyFlowHook"
+  shared_flow_name = apigee_shared_flow.test.name
+}
+`, testAccFixture(t, "shared_flow")),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_flow_hook.test", testAccFlowHookPath),
+						resource.TestCheckResourceAttr("apigee_flow_hook.test", "id", "test:PreProxyFlowHook"),
+						resource.TestCheckResourceAttr("apigee_flow_hook.test", "continue_on_error", "true"),
+					),
+				},
+				{
+					ResourceName:      "apigee_flow_hook.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
+func testAccFlowHookPath(rs *terraform.ResourceState) string {
+	envName, flowHookPoint := client.FlowHookDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.FlowHookPathGet, testAccOrganization, envName, flowHookPoint)
+}
@@ -0,0 +1,116 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceInstanceAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInstanceAttachmentCreate,
+		ReadContext:   resourceInstanceAttachmentRead,
+		DeleteContext: resourceInstanceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultOperationTimeout),
+			Delete: schema.DefaultTimeout(DefaultOperationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"instance_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func findInstanceAttachment(c *client.Client, instanceName string, envName string) (*client.InstanceAttachment, error) {
+	//Attachment names are generated by Google so look the attachment up by environment
+	attachments, err := c.ListInstanceAttachments(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		if attachment.EnvironmentName == envName {
+			attachment.InstanceName = instanceName
+			return &attachment, nil
+		}
+	}
+	return nil, nil
+}
+
+func resourceInstanceAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	if !c.IsGoogle() {
+		return diag.Errorf("apigee_instance_attachment is only supported for Google Cloud Apigee versions")
+	}
+	newAttachment := client.InstanceAttachment{
+		InstanceName:    d.Get("instance_name").(string),
+		EnvironmentName: d.Get("environment_name").(string),
+	}
+	op, err := c.CreateInstanceAttachment(&newAttachment)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newAttachment.InstanceAttachmentEncodeId())
+	err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceInstanceAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	instanceName, envName := client.InstanceAttachmentDecodeId(d.Id())
+	c := m.(*client.Client)
+	retVal, err := findInstanceAttachment(c, instanceName, envName)
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	if retVal == nil {
+		d.SetId("")
+		return diags
+	}
+	d.Set("instance_name", instanceName)
+	d.Set("environment_name", envName)
+	return diags
+}
+
+func resourceInstanceAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	instanceName, envName := client.InstanceAttachmentDecodeId(d.Id())
+	c := m.(*client.Client)
+	retVal, err := findInstanceAttachment(c, instanceName, envName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if retVal != nil {
+		op, err := c.DeleteInstanceAttachment(instanceName, retVal.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = waitForOperation(ctx, c, op, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccInstanceAttachment(t *testing.T) {
+	//Instances cannot be managed by the provider, so one is seeded
+	fake := newFakeApigee(t, true)
+	fake.seed(fmt.Sprintf(client.InstancePathGet, testAccOrganization, "acctest"), map[string]interface{}{
+		"name": "acctest",
+	})
+	path := func(rs *terraform.ResourceState) string {
+		instanceName, envName := client.InstanceAttachmentDecodeId(rs.Primary.ID)
+		return fake.attachmentPath(fmt.Sprintf(client.InstanceAttachmentPath, testAccOrganization, instanceName), envName)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_instance_attachment", path),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_instance_attachment" "test" {
+  instance_name    = "acctest"
+  environment_name = "test"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_instance_attachment.test", path),
+					resource.TestCheckResourceAttr("apigee_instance_attachment.test", "id", "acctest:test"),
+				),
+			},
+			{
+				ResourceName:      "apigee_instance_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccKeystore(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_keystore", testAccKeystorePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_keystore" "test" {
+  environment_name = "test"
+  name             = "acctest"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_keystore.test", testAccKeystorePath),
+					resource.TestCheckResourceAttr("apigee_keystore.test", "id", "test:acctest"),
+				),
+			},
+			{
+				ResourceName:      "apigee_keystore.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccKeystorePath(rs *terraform.ResourceState) string {
+	envName, name := client.KeystoreDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.KeystorePathGet, testAccOrganization, envName, name)
+}
@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceOrganizationKVM() *schema.Resource {
@@ -56,21 +51,11 @@ func resourceOrganizationKVM() *schema.Resource {
 func resourceOrganizationKVMCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newOrganizationKVM := client.KVM{
 		Name: d.Get("name").(string),
 	}
 	fillOrganizationKVM(&newOrganizationKVM, d)
-	err := json.NewEncoder(&buf).Encode(newOrganizationKVM)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.OrganizationKVMPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateOrganizationKVM(&newOrganizationKVM)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -104,22 +89,14 @@ func fillOrganizationKVM(c *client.KVM, d *schema.ResourceData) {
 func resourceOrganizationKVMRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.OrganizationKVMPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetOrganizationKVM(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.KVM{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("name", d.Id())
 	d.Set("encrypted", retVal.Encrypted)
 	entries := map[string]string{}
@@ -155,15 +132,11 @@ func resourceOrganizationKVMUpdate(ctx context.Context, d *schema.ResourceData,
 			continue
 		}
 		//Delete entry
-		requestPath := fmt.Sprintf(client.OrganizationKVMPathEntriesGet, c.Organization, d.Id(), oldKey)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err := c.DeleteOrganizationKVMEntry(d.Id(), oldKey)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
 	//Public Apigee requires entries to be added/changed individually
 	if c.IsPublic() {
 		//Check for addition/modification of entries
@@ -177,40 +150,28 @@ func resourceOrganizationKVMUpdate(ctx context.Context, d *schema.ResourceData,
 					continue
 				}
 			}
-			buf := bytes.Buffer{}
 			newOrModEntry := client.Attribute{
 				Name:  newKey,
 				Value: newOrModValue,
 			}
-			err := json.NewEncoder(&buf).Encode(newOrModEntry)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			requestPath := ""
+			var err error
 			if oldHasKey {
 				//Change entry
-				requestPath = fmt.Sprintf(client.OrganizationKVMPathEntriesGet, c.Organization, d.Id(), newKey)
+				err = c.UpdateOrganizationKVMEntry(d.Id(), &newOrModEntry)
 			} else {
 				//Add entry
-				requestPath = fmt.Sprintf(client.OrganizationKVMPathEntries, c.Organization, d.Id())
+				err = c.CreateOrganizationKVMEntry(d.Id(), &newOrModEntry)
 			}
-			_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	} else {
-		buf := bytes.Buffer{}
 		upOrganizationKVM := client.KVM{
 			Name: d.Id(),
 		}
 		fillOrganizationKVM(&upOrganizationKVM, d)
-		err := json.NewEncoder(&buf).Encode(upOrganizationKVM)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		requestPath := fmt.Sprintf(client.OrganizationKVMPathGet, c.Organization, d.Id())
-		_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+		err := c.UpdateOrganizationKVM(&upOrganizationKVM)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -221,8 +182,7 @@ func resourceOrganizationKVMUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceOrganizationKVMDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.OrganizationKVMPathGet, c.Organization, d.Id())
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteOrganizationKVM(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccOrganizationKVM(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_organization_kvm", testAccOrganizationKVMPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_organization_kvm" "test" {
+  name = "acctest"
+  entry = {
+    key1 = "value1"
+    key2 = "value2"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_organization_kvm.test", testAccOrganizationKVMPath),
+					resource.TestCheckResourceAttr("apigee_organization_kvm.test", "id", "acctest"),
+					resource.TestCheckResourceAttr("apigee_organization_kvm.test", "entry.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_organization_kvm.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOrganizationKVMPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.OrganizationKVMPathGet, testAccOrganization, rs.Primary.ID)
+}
@@ -1,16 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 )
 
 func resourceOrganizationResourceFile() *schema.Resource {
@@ -52,24 +46,7 @@ func resourceOrganizationResourceFileCreate(ctx context.Context, d *schema.Resou
 		Type: d.Get("type").(string),
 		Name: d.Get("name").(string),
 	}
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.OrganizationResourceFilePath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
-	}
-	requestQuery := url.Values{
-		"type": []string{newOrganizationResourceFile.Type},
-		"name": []string{newOrganizationResourceFile.Name},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err := c.CreateOrganizationResourceFile(&newOrganizationResourceFile, d.Get("file").(string))
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -83,25 +60,17 @@ func resourceOrganizationResourceFileRead(ctx context.Context, d *schema.Resourc
 	rtype, name := client.OrganizationResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Reading specific file returns actual contents of file so read all files of type and search for name instead
-	requestPath := fmt.Sprintf(client.OrganizationResourceFilePathOfType, c.Organization, rtype)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListOrganizationResourceFilesOfType(rtype)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.ResourceFilesOfType{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	//Look for existence of name
 	found := false
-	for _, file := range retVal.Files {
+	for _, file := range retVal {
 		if file.Name == name {
 			found = true
 			break
@@ -120,19 +89,11 @@ func resourceOrganizationResourceFileUpdate(ctx context.Context, d *schema.Resou
 	var diags diag.Diagnostics
 	rtype, name := client.OrganizationResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.OrganizationResourceFilePathGet, c.Organization, rtype, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
+	upOrganizationResourceFile := client.ResourceFile{
+		Type: rtype,
+		Name: name,
 	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, buf)
+	err := c.UpdateOrganizationResourceFile(&upOrganizationResourceFile, d.Get("file").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -143,8 +104,7 @@ func resourceOrganizationResourceFileDelete(ctx context.Context, d *schema.Resou
 	var diags diag.Diagnostics
 	rtype, name := client.OrganizationResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.OrganizationResourceFilePathGet, c.Organization, rtype, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteOrganizationResourceFile(rtype, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccOrganizationResourceFile(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_organization_resource_file", testAccOrganizationResourceFilePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_organization_resource_file" "test" {
+  type      = "jsc"
+  name      = "acctest.js"
+  file      = %q
+  file_hash = "1"
+}
+`, testAccFixture(t, "resource.js")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_organization_resource_file.test", testAccOrganizationResourceFilePath),
+					resource.TestCheckResourceAttr("apigee_organization_resource_file.test", "id", "jsc:acctest.js"),
+				),
+			},
+			{
+				ResourceName:            "apigee_organization_resource_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccOrganizationResourceFilePath(rs *terraform.ResourceState) string {
+	rtype, name := client.OrganizationResourceFileDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.OrganizationResourceFilePathGet, testAccOrganization, rtype, name)
+}
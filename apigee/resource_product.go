@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 	"regexp"
 	"strconv"
 
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -156,7 +151,6 @@ func resourceProduct() *schema.Resource {
 func resourceProductCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	autoApprovalType := d.Get("auto_approval_type").(bool)
 	approvalType := ""
 	if autoApprovalType {
@@ -170,16 +164,7 @@ func resourceProductCreate(_ context.Context, d *schema.ResourceData, m interfac
 		ApprovalType: approvalType,
 	}
 	fillProduct(&newProduct, d)
-	err := json.NewEncoder(&buf).Encode(newProduct)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProductPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateProduct(&newProduct)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -305,22 +290,14 @@ func readOperationsConfig(c client.OperationGroup) []interface{} {
 func resourceProductRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProductPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetProduct(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Product{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("name", d.Id())
 	d.Set("display_name", retVal.DisplayName)
 	d.Set("auto_approval_type", retVal.ApprovalType == "auto")
@@ -353,7 +330,6 @@ func resourceProductRead(_ context.Context, d *schema.ResourceData, m interface{
 func resourceProductUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	autoApprovalType := d.Get("auto_approval_type").(bool)
 	approvalType := ""
 	if autoApprovalType {
@@ -367,15 +343,7 @@ func resourceProductUpdate(_ context.Context, d *schema.ResourceData, m interfac
 		ApprovalType: approvalType,
 	}
 	fillProduct(&upProduct, d)
-	err := json.NewEncoder(&buf).Encode(upProduct)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProductPathGet, c.Organization, d.Id())
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateProduct(d.Id(), &upProduct)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -385,8 +353,7 @@ func resourceProductUpdate(_ context.Context, d *schema.ResourceData, m interfac
 func resourceProductDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProductPathGet, c.Organization, d.Id())
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteProduct(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,50 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccProduct(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_product", testAccProductPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_product" "test" {
+  name               = "acctest"
+  display_name       = "Acceptance Test"
+  auto_approval_type = true
+  description        = "Acceptance test product"
+  environments       = ["test"]
+  scopes             = ["read"]
+  attributes = {
+    access = "public"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_product.test", testAccProductPath),
+					resource.TestCheckResourceAttr("apigee_product.test", "id", "acctest"),
+					resource.TestCheckResourceAttr("apigee_product.test", "auto_approval_type", "true"),
+					resource.TestCheckResourceAttr("apigee_product.test", "attributes.access", "public"),
+				),
+			},
+			{
+				ResourceName:      "apigee_product.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccProductPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.ProductPathGet, testAccOrganization, rs.Primary.ID)
+}
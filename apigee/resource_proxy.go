@@ -1,17 +1,13 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 	"strconv"
+	"strings"
 )
 
 func resourceProxy() *schema.Resource {
@@ -30,67 +26,119 @@ func resourceProxy() *schema.Resource {
 				ForceNew: true,
 			},
 			"bundle": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"bundle", "bundle_dir"},
+				RequiredWith: []string{"bundle_hash"},
+			},
+			"bundle_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"bundle", "bundle_dir"},
 			},
 			"bundle_hash": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"bundle_dir"},
+			},
+			"keep_revisions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"delete_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{DeleteBehaviorSkipIfDeployed, DeleteBehaviorUndeployAndDelete, DeleteBehaviorFailIfDeployed}, false),
+			},
+			"skip_bundle_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 			"revision": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"base_paths": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"proxy_endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"target_endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"resource_files": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_modified_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 		CustomizeDiff: resourceProxyCustomDiff,
 	}
 }
 
-func resourceProxyCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
-	//Mark the revision as changing if bundle changes
-	if diff.HasChange("bundle") {
-		diff.SetNewComputed("revision")
-	}
-	if diff.HasChange("bundle_hash") {
-		diff.SetNewComputed("revision")
-	}
-	return nil
-}
+var proxyRevisionDetailKeys = []string{"base_paths", "proxy_endpoints", "target_endpoints", "policies", "resource_files", "created_at", "last_modified_at", "description"}
 
-func importProxyRevision(c *client.Client, name string, bundle string) (*client.ProxyRevision, error) {
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"bundle": client.FormData{Filename: bundle},
-	})
+func resourceProxyCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	err := customizeBundleDirDiff(diff, client.ProxyBundleRoot)
 	if err != nil {
-		return nil, err
-	}
-	requestPath := fmt.Sprintf(client.ProxyPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
+		return err
 	}
-	requestQuery := url.Values{
-		"action": []string{"import"},
-		"name":   []string{name},
-	}
-	body, err := c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err = validateBundleDiff(diff, client.ProxyBundleRoot)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	retVal := &client.ProxyRevision{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		return nil, err
+	//Mark the revision and everything read from it as changing if bundle changes
+	if bundleChanged(diff) {
+		diff.SetNewComputed("revision")
+		diff.SetNewComputed("revisions")
+		for _, key := range proxyRevisionDetailKeys {
+			diff.SetNewComputed(key)
+		}
 	}
-	return retVal, nil
+	return nil
 }
 
 func resourceProxyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
 	name := d.Get("name").(string)
-	bundle := d.Get("bundle").(string)
-	retVal, err := importProxyRevision(c, name, bundle)
+	bundle, err := getBundleFormData(d, client.ProxyBundleRoot)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal, err := c.ImportProxy(name, bundle)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -98,48 +146,36 @@ func resourceProxyCreate(ctx context.Context, d *schema.ResourceData, m interfac
 	d.SetId(name)
 	revision, _ := strconv.Atoi(retVal.Revision)
 	d.Set("revision", revision)
+	diags = append(diags, pruneProxyRevisions(c, d)...)
+	diags = append(diags, readProxyRevisions(c, d)...)
 	return diags
 }
 
 func resourceProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetProxy(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Proxy{}
-	err = json.NewDecoder(body).Decode(retVal)
+	d.Set("name", d.Id())
+	revisions, err := sortRevisions(retVal.Revisions)
 	if err != nil {
-		d.SetId("")
 		return diag.FromErr(err)
 	}
-	d.Set("name", d.Id())
-	//Empirically the revisions array is sorted *alphabetically* when we get
-	//it which means that, for example, an API with 10 revisions comes back
-	//with a revision list of 1, 10, 2, 3, 4, 5, 6, 7, 8, 9. As such we need
-	//to iteratate over the entire array to determine the latest revision.
-	//Any errors which arise parsing revision numbers are returned as a
-	//diagnostic.
-	revision := 0
-	for _, revisionStr := range retVal.Revisions {
-		rn, err := strconv.Atoi(revisionStr)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if rn > revision {
-			revision = rn
-		}
-	}
-	if revision == 0 {
+	if len(revisions) == 0 {
 		return diag.Errorf("proxy has no latest revision")
 	}
+	revision := revisions[len(revisions)-1]
+	d.Set("revisions", revisions)
+	err = setProxyRevisionDetail(c, d, revision)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set("revision", revision)
 	return diags
 }
@@ -147,54 +183,176 @@ func resourceProxyRead(ctx context.Context, d *schema.ResourceData, m interface{
 func resourceProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	bundle := d.Get("bundle").(string)
-	retVal, err := importProxyRevision(c, d.Id(), bundle)
-	if err != nil {
-		return diag.FromErr(err)
+	//Only import a new revision if the bundle itself changed
+	if bundleChanged(d) {
+		bundle, err := getBundleFormData(d, client.ProxyBundleRoot)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		retVal, err := c.ImportProxy(d.Id(), bundle)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		revision, _ := strconv.Atoi(retVal.Revision)
+		d.Set("revision", revision)
 	}
-	revision, _ := strconv.Atoi(retVal.Revision)
-	d.Set("revision", revision)
+	diags = append(diags, pruneProxyRevisions(c, d)...)
+	diags = append(diags, readProxyRevisions(c, d)...)
 	return diags
 }
 
 func resourceProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	//Assume that if this proxy is currently deployed to ANY environment, that it was deployed in a different
-	//TF configuration.  Therefore, that other TF configuration will handle the delete of the proxy so just
-	//report deletion to TF even though it was not deleted from Apigee.  This is a design decision which
-	//could be solved other ways, but would be much more complicated for the user.
 	//Get all deployments of this proxy to ANY environment
-	requestPath := fmt.Sprintf(client.ProxyDeploymentPath, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	deployedRevisions, err := getProxyDeployedRevisions(c, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	var deployments interface{}
-	if c.IsGoogle() {
-		deployments = &client.GoogleProxyEnvironmentDeployment{}
-	} else {
-		deployments = &client.ProxyDeployments{}
+	if len(deployedRevisions) > 0 {
+		deleteBehavior := d.Get("delete_behavior").(string)
+		if deleteBehavior == "" {
+			deleteBehavior = DeleteBehaviorSkipIfDeployed
+		}
+		envNames := strings.Join(deployedEnvironments(deployedRevisions), ", ")
+		switch deleteBehavior {
+		case DeleteBehaviorSkipIfDeployed:
+			//Assume that the deployments belong to a different TF configuration which will handle the delete,
+			//so report deletion to TF even though it was not deleted from Apigee
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Proxy " + d.Id() + " was not deleted because it is deployed",
+				Detail:   "Deployed to environments: " + envNames,
+			})
+			d.SetId("")
+			return diags
+		case DeleteBehaviorFailIfDeployed:
+			return diag.Errorf("proxy %s cannot be deleted because it is deployed to environments: %s", d.Id(), envNames)
+		case DeleteBehaviorUndeployAndDelete:
+			for revision, envs := range deployedRevisions {
+				for _, envName := range envs {
+					err := c.UndeployProxyRevision(envName, d.Id(), revision)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
 	}
-	err = json.NewDecoder(body).Decode(deployments)
+	err = c.DeleteProxy(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	var numDeployments int
+	d.SetId("")
+	return diags
+}
+
+func getProxyDeployedRevisions(c *client.Client, name string) (map[int][]string, error) {
+	//Map each deployed revision to the environments it is deployed to
+	retVal := map[int][]string{}
 	if c.IsGoogle() {
-		googleDeployments := deployments.(*client.GoogleProxyEnvironmentDeployment)
-		numDeployments = len(googleDeployments.Deployments)
+		googleDeployments, err := c.GetGoogleProxyDeployments(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range googleDeployments.Deployments {
+			revision, err := strconv.Atoi(dep.Revision)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = append(retVal[revision], dep.EnvironmentName)
+		}
 	} else {
-		oldDeployments := deployments.(*client.ProxyDeployments)
-		numDeployments = len(oldDeployments.Environments)
+		oldDeployments, err := c.GetProxyDeployments(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, env := range oldDeployments.Environments {
+			for _, rev := range env.Revisions {
+				revision, err := strconv.Atoi(rev.Name)
+				if err != nil {
+					return nil, err
+				}
+				retVal[revision] = append(retVal[revision], env.EnvironmentName)
+			}
+		}
 	}
-	if numDeployments == 0 {
-		requestPath = fmt.Sprintf(client.ProxyPathGet, c.Organization, d.Id())
-		_, err = c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	return retVal, nil
+}
+
+func pruneProxyRevisions(c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	//The import already succeeded so failing to prune is only reported as a warning
+	var diags diag.Diagnostics
+	keepRevisions := d.Get("keep_revisions").(int)
+	if keepRevisions == 0 {
+		return diags
+	}
+	proxy, err := c.GetProxy(d.Id())
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	revisions, err := sortRevisions(proxy.Revisions)
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	if len(revisions) <= keepRevisions {
+		return diags
+	}
+	//Never delete a revision deployed to ANY environment, even if it is older than the newest N
+	deployedRevisions, err := getProxyDeployedRevisions(c, d.Id())
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	for _, revision := range revisions[:len(revisions)-keepRevisions] {
+		if _, deployed := deployedRevisions[revision]; deployed {
+			continue
+		}
+		err = c.DeleteProxyRevision(d.Id(), revision)
 		if err != nil {
-			return diag.FromErr(err)
+			return append(diags, pruneWarning(d.Id(), err))
 		}
 	}
-	d.SetId("")
 	return diags
 }
+
+func pruneWarning(name string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Unable to delete old revisions of " + name,
+		Detail:   err.Error(),
+	}
+}
+
+func readProxyRevisions(c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	//Refresh the computed revision attributes after an import or prune
+	proxy, err := c.GetProxy(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	revisions, err := sortRevisions(proxy.Revisions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("revisions", revisions)
+	err = setProxyRevisionDetail(c, d, d.Get("revision").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func setProxyRevisionDetail(c *client.Client, d *schema.ResourceData, revision int) error {
+	detail, err := c.GetProxyRevision(d.Id(), revision)
+	if err != nil {
+		return err
+	}
+	d.Set("base_paths", detail.BasePaths)
+	d.Set("proxy_endpoints", detail.ProxyEndpoints)
+	d.Set("target_endpoints", detail.TargetEndpoints)
+	d.Set("policies", detail.Policies)
+	d.Set("resource_files", detail.Resources)
+	d.Set("created_at", formatEpochMillis(detail.CreatedAt))
+	d.Set("last_modified_at", formatEpochMillis(detail.LastModifiedAt))
+	d.Set("description", detail.Description)
+	return nil
+}
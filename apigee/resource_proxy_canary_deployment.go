@@ -0,0 +1,248 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func resourceProxyCanaryDeployment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProxyCanaryDeploymentCreate,
+		ReadContext:   resourceProxyCanaryDeploymentRead,
+		UpdateContext: resourceProxyCanaryDeploymentUpdate,
+		DeleteContext: resourceProxyCanaryDeploymentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceProxyCanaryDeploymentCustomDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultDeploymentTimeout),
+			Update: schema.DefaultTimeout(DefaultDeploymentTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"proxy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"environment_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"stable_revision": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"candidate_revision": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"deployed_revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceProxyCanaryDeploymentCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	c := m.(*client.Client)
+	//Google Cloud Apigee only allows one revision of a proxy to be deployed to an environment at a time, so there the candidate is rolled out by changing stable_revision
+	if c.IsGoogle() && (diff.Get("candidate_revision").(int) != 0) {
+		return fmt.Errorf("candidate_revision is not supported for Google Cloud Apigee versions since only one revision of a proxy can be deployed to an environment at a time, roll out by changing stable_revision instead")
+	}
+	if diff.Get("stable_revision").(int) == diff.Get("candidate_revision").(int) {
+		return fmt.Errorf("candidate_revision must be different from stable_revision")
+	}
+	if diff.HasChange("stable_revision") || diff.HasChange("candidate_revision") {
+		diff.SetNewComputed("deployed_revisions")
+	}
+	return nil
+}
+
+func resourceProxyCanaryDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	newProxyDeployment := client.ProxyEnvironmentDeployment{
+		EnvironmentName: d.Get("environment_name").(string),
+		ProxyName:       d.Get("proxy_name").(string),
+	}
+	d.SetId(newProxyDeployment.ProxyDeploymentEncodeId())
+	diags := reconcileProxyCanaryDeployment(ctx, c, d, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() && (len(d.Get("deployed_revisions").([]interface{})) == 0) {
+		//Nothing was deployed so there is nothing to track
+		d.SetId("")
+	}
+	return diags
+}
+
+func resourceProxyCanaryDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
+	c := m.(*client.Client)
+	revisions, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	//Nothing deployed means the deployment is gone and must be recreated
+	if len(revisions) == 0 {
+		d.SetId("")
+		return diags
+	}
+	d.Set("environment_name", envName)
+	d.Set("proxy_name", proxyName)
+	d.Set("deployed_revisions", revisions)
+	//Keep the configured roles while those revisions are still live, otherwise infer them from what is deployed
+	stableRevision := d.Get("stable_revision").(int)
+	if _, found := findRevision(revisions, stableRevision); !found {
+		stableRevision = revisions[0]
+	}
+	candidateRevision := d.Get("candidate_revision").(int)
+	if _, found := findRevision(revisions, candidateRevision); !found || (candidateRevision == stableRevision) {
+		candidateRevision = 0
+		for i := len(revisions) - 1; i >= 0; i-- {
+			if revisions[i] != stableRevision {
+				candidateRevision = revisions[i]
+				break
+			}
+		}
+	}
+	d.Set("stable_revision", stableRevision)
+	d.Set("candidate_revision", candidateRevision)
+	return diags
+}
+
+func resourceProxyCanaryDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	return reconcileProxyCanaryDeployment(ctx, c, d, d.Timeout(schema.TimeoutUpdate))
+}
+
+func resourceProxyCanaryDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
+	c := m.(*client.Client)
+	revisions, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, revision := range revisions {
+		err := c.UndeployProxyRevision(envName, proxyName, revision)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
+
+func reconcileProxyCanaryDeployment(ctx context.Context, c *client.Client, d *schema.ResourceData, timeout time.Duration) diag.Diagnostics {
+	//Deploy the stable and candidate revisions side by side, then undeploy anything else
+	//Promoting is setting stable_revision to the candidate and removing candidate_revision, aborting is just removing candidate_revision
+	var diags diag.Diagnostics
+	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
+	desired := []int{d.Get("stable_revision").(int)}
+	if d.Get("candidate_revision").(int) != 0 {
+		desired = append(desired, d.Get("candidate_revision").(int))
+	}
+	if len(desired) > 1 {
+		err := checkCanaryBasePaths(c, proxyName, desired[0], desired[1])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	current, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous := append([]int{}, current...)
+	deadline := time.Now().Add(timeout)
+	for _, revision := range desired {
+		if _, found := findRevision(current, revision); found {
+			continue
+		}
+		//Never override on Edge, since that would undeploy the other revision
+		requestForm := url.Values{}
+		if c.IsGoogle() {
+			//Google swaps the single deployed revision, so check the change report for other proxies using its base paths first
+			conflicts, err := findProxyBasePathConflicts(c, envName, proxyName, revision)
+			if err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			if len(conflicts) > 0 {
+				return append(diags, diag.Errorf("revision %d of proxy %s conflicts with existing deployments in environment %s:\n  %s", revision, proxyName, envName, strings.Join(conflicts, "\n  "))...)
+			}
+			requestForm["override"] = []string{strconv.FormatBool(true)}
+		}
+		err = c.DeployProxyRevision(envName, proxyName, revision, requestForm)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		current = append(current, revision)
+		d.Set("deployed_revisions", current)
+		err = waitForDeployment(ctx, time.Until(deadline), func() (*client.DeploymentStatus, error) {
+			return c.GetProxyDeploymentStatus(envName, proxyName, revision)
+		})
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	//An override may already have replaced revisions, so undeploy whatever else is still live
+	current, err = getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	for _, revision := range current {
+		if _, found := findRevision(desired, revision); found {
+			continue
+		}
+		err = c.UndeployProxyRevision(envName, proxyName, revision)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	revisions, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.Set("deployed_revisions", revisions)
+	undeployed := []int{}
+	for _, revision := range previous {
+		if _, found := findRevision(revisions, revision); !found {
+			undeployed = append(undeployed, revision)
+		}
+	}
+	if len(undeployed) > 0 {
+		diags = append(diags, undeployedWarning(proxyName, envName, undeployed))
+	}
+	return diags
+}
+
+func checkCanaryBasePaths(c *client.Client, proxyName string, stableRevision int, candidateRevision int) error {
+	//Edge can only route to both revisions if their base paths differ
+	stable, err := c.GetProxyRevision(proxyName, stableRevision)
+	if err != nil {
+		return err
+	}
+	candidate, err := c.GetProxyRevision(proxyName, candidateRevision)
+	if err != nil {
+		return err
+	}
+	for _, basePath := range candidate.BasePaths {
+		if _, found := find(stable.BasePaths, basePath); found {
+			return fmt.Errorf("revisions %d and %d of proxy %s both use base path %s, so they cannot be deployed together", stableRevision, candidateRevision, proxyName, basePath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAccProxyCanaryDeployment(t *testing.T) {
+	//Edge deploys both revisions side by side, which needs different base paths
+	fake := newFakeApigee(t, false)
+	fake.addRevision("apis", "acctest", "/v1")
+	fake.addRevision("apis", "acctest", "/v2")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_canary_deployment", testAccProxyCanaryDeploymentPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_proxy_canary_deployment" "test" {
+  proxy_name         = "acctest"
+  environment_name   = "test"
+  stable_revision    = 1
+  candidate_revision = 2
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_proxy_canary_deployment.test", testAccProxyCanaryDeploymentPath),
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "id", "test:acctest"),
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "deployed_revisions.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "apigee_proxy_canary_deployment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccProxyCanaryDeploymentGoogle(t *testing.T) {
+	//Google only deploys one revision at a time, so the rollout is a change of stable_revision
+	fake := newFakeApigee(t, true)
+	fake.addRevision("apis", "acctest", "/v1")
+	fake.addRevision("apis", "acctest", "/v1")
+	config := func(revisions string) string {
+		return testAccProviderConfig(fake) + `
+resource "apigee_proxy_canary_deployment" "test" {
+  proxy_name       = "acctest"
+  environment_name = "test"
+` + revisions + `
+}
+`
+	}
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_canary_deployment", testAccProxyCanaryDeploymentStablePath),
+		Steps: []resource.TestStep{
+			{
+				Config: config("  stable_revision = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "deployed_revisions.#", "1"),
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "deployed_revisions.0", "1"),
+				),
+			},
+			{
+				Config:      config("  stable_revision    = 1\n  candidate_revision = 2"),
+				ExpectError: regexp.MustCompile("candidate_revision is not supported"),
+			},
+			{
+				Config: config("  stable_revision = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_proxy_canary_deployment.test", testAccProxyCanaryDeploymentStablePath),
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "deployed_revisions.#", "1"),
+					resource.TestCheckResourceAttr("apigee_proxy_canary_deployment.test", "deployed_revisions.0", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestReconcileProxyCanaryDeploymentGoogle(t *testing.T) {
+	fake := newFakeApigee(t, true)
+	c := fake.client(t)
+	previous := fake.addRevision("apis", "acctest", "/v1")
+	revision := fake.addRevision("apis", "acctest", "/v1")
+	err := c.DeployProxyRevision(testAccEnvironment, "acctest", previous, url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceProxyCanaryDeployment().Schema, map[string]interface{}{
+		"proxy_name":       "acctest",
+		"environment_name": testAccEnvironment,
+		"stable_revision":  revision,
+	})
+	d.SetId(testAccEnvironment + client.IdSeparator + "acctest")
+	diags := reconcileProxyCanaryDeployment(context.Background(), c, d, time.Minute)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	got := d.Get("deployed_revisions").([]interface{})
+	if (len(got) != 1) || (got[0].(int) != revision) {
+		t.Errorf("expected only revision %d to be deployed, got %v", revision, got)
+	}
+	if (len(diags) != 1) || (diags[0].Detail != "Undeployed revisions: "+strconv.Itoa(previous)) {
+		t.Errorf("expected revision %d to be reported as undeployed, got %v", previous, diags)
+	}
+	//Another proxy on the same base path shows up in the change report and stops the rollout
+	other := fake.addRevision("apis", "other", "/v1")
+	err = c.DeployProxyRevision(testAccEnvironment, "other", other, url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Set("stable_revision", previous)
+	diags = reconcileProxyCanaryDeployment(context.Background(), c, d, time.Minute)
+	if !diags.HasError() {
+		t.Errorf("expected the base path conflict with proxy other to fail the rollout")
+	}
+}
+
+func testAccProxyCanaryDeploymentStablePath(rs *terraform.ResourceState) string {
+	envName, proxyName := client.ProxyDeploymentDecodeId(rs.Primary.ID)
+	revision, _ := strconv.Atoi(rs.Primary.Attributes["stable_revision"])
+	return fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, testAccOrganization, envName, proxyName, revision)
+}
+
+func testAccProxyCanaryDeploymentPath(rs *terraform.ResourceState) string {
+	envName, proxyName := client.ProxyDeploymentDecodeId(rs.Primary.ID)
+	revision, _ := strconv.Atoi(rs.Primary.Attributes["candidate_revision"])
+	return fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, testAccOrganization, envName, proxyName, revision)
+}
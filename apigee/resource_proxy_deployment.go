@@ -1,19 +1,23 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	DeploymentStrategyOverride       = "override"
+	DeploymentStrategyUndeployFirst  = "undeploy_first"
+	DeploymentStrategyFailOnConflict = "fail_on_conflict"
 )
 
 func resourceProxyDeployment() *schema.Resource {
@@ -25,6 +29,11 @@ func resourceProxyDeployment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceProxyDeploymentCustomDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultDeploymentTimeout),
+			Update: schema.DefaultTimeout(DefaultDeploymentTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"proxy_name": {
 				Type:     schema.TypeString,
@@ -51,6 +60,16 @@ func resourceProxyDeployment() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"deployed_revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"deployment_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{DeploymentStrategyOverride, DeploymentStrategyUndeployFirst, DeploymentStrategyFailOnConflict}, false),
+			},
 		},
 	}
 }
@@ -60,10 +79,17 @@ func resourceProxyDelayDiff(k string, old string, n string, d *schema.ResourceDa
 	return true
 }
 
+func resourceProxyDeploymentCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	//Deploying a different revision replaces whatever is live
+	if diff.HasChange("revision") {
+		diff.SetNewComputed("deployed_revisions")
+	}
+	return nil
+}
+
 func resourceProxyDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	v := url.Values{}
+	requestForm := url.Values{}
 	newProxyDeployment := client.ProxyEnvironmentDeployment{
 		EnvironmentName: d.Get("environment_name").(string),
 		ProxyName:       d.Get("proxy_name").(string),
@@ -73,65 +99,45 @@ func resourceProxyDeploymentCreate(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("service_account cannot be set for non-Google Cloud Apigee versions")
 		}
 		newProxyDeployment.ServiceAccount = d.Get("service_account").(string)
-		v.Set("serviceAccount", newProxyDeployment.ServiceAccount)
+		requestForm.Set("serviceAccount", newProxyDeployment.ServiceAccount)
 	}
 	revision := d.Get("revision").(int)
-	requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, c.Organization, newProxyDeployment.EnvironmentName, newProxyDeployment.ProxyName, revision)
-	_, err := c.HttpRequest(http.MethodPost, requestPath, v, nil, &bytes.Buffer{})
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	d.SetId(newProxyDeployment.ProxyDeploymentEncodeId())
-	return diags
+	//The id is only set once the deploy succeeds so a failed wait still tracks the deployment
+	return deployProxyRevisionWithStrategy(ctx, c, d, newProxyDeployment.EnvironmentName, newProxyDeployment.ProxyName, revision, requestForm, d.Timeout(schema.TimeoutCreate), func() {
+		d.SetId(newProxyDeployment.ProxyDeploymentEncodeId())
+	})
 }
 
 func resourceProxyDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentPath, c.Organization, envName, proxyName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	deployedRevisions, err := getProxyEnvironmentDeployedRevisions(c, envName, proxyName)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
-			return diags
-		}
 		return diag.FromErr(err)
 	}
-	var retVal interface{}
-	if c.IsGoogle() {
-		retVal = &client.GoogleProxyEnvironmentDeployment{}
-	} else {
-		retVal = &client.ProxyEnvironmentDeployment{}
-	}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
+	//Nothing deployed means the deployment is gone and must be recreated
+	if len(deployedRevisions) == 0 {
 		d.SetId("")
-		return diag.FromErr(err)
+		return diags
 	}
 	d.Set("environment_name", envName)
 	d.Set("proxy_name", proxyName)
-	lastRevision := ""
-	//Retrieve the latest revision deployed as THE revision, assumes array is sorted
-	if c.IsGoogle() {
-		googleRetVal := retVal.(*client.GoogleProxyEnvironmentDeployment)
-		lastRevision = googleRetVal.Deployments[len(googleRetVal.Deployments)-1].Revision
-	} else {
-		oldRetVal := retVal.(*client.ProxyEnvironmentDeployment)
-		lastRevision = oldRetVal.Revisions[len(oldRetVal.Revisions)-1].Name
+	revisions := []int{}
+	for revision := range deployedRevisions {
+		revisions = append(revisions, revision)
 	}
-	revision, _ := strconv.Atoi(lastRevision)
-	d.Set("revision", revision)
-	if c.IsGoogle() {
-		googleRetVal := retVal.(*client.GoogleProxyEnvironmentDeployment)
-		serviceAccount := googleRetVal.Deployments[len(googleRetVal.Deployments)-1].ServiceAccount
-		//When reading the service account, it is prefixed by "projects/-/serviceAccounts/"
-		d.Set("service_account", strings.TrimPrefix(serviceAccount, "projects/-/serviceAccounts/"))
-	} else {
-		d.Set("service_account", "")
+	sort.Ints(revisions)
+	//During a rollout more than one revision can be live, so keep the configured revision while it is one of them, otherwise the highest one is THE revision
+	revision := d.Get("revision").(int)
+	if _, found := deployedRevisions[revision]; !found {
+		revision = revisions[len(revisions)-1]
 	}
+	d.Set("revision", revision)
+	d.Set("deployed_revisions", revisions)
+	//When reading the service account, it is prefixed by "projects/-/serviceAccounts/"
+	d.Set("service_account", strings.TrimPrefix(deployedRevisions[revision], client.GoogleServiceAccountPrefix))
 	return diags
 }
 
@@ -139,20 +145,19 @@ func resourceProxyDeploymentUpdate(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
 	c := m.(*client.Client)
-	//Grab revision of existing deployment in case I need to manually undeploy later if the basepath has changed
-	prevRevision := 0
-	if d.HasChange("revision") {
-		o, _ := d.GetChange("revision")
-		prevRevision = o.(int)
+	//Changing only the strategy does not need a new deployment
+	if !d.HasChanges("revision", "service_account") {
+		return diags
 	}
 	revision := d.Get("revision").(int)
 	delay := d.Get("delay").(int)
-	requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, revision)
-	requestForm := url.Values{
-		"override": []string{strconv.FormatBool(true)},
-	}
-	if !c.IsGoogle() {
-		requestForm["delay"] = []string{strconv.Itoa(delay)}
+	requestForm := url.Values{}
+	//Under undeploy_first nothing is live when the new revision is deployed, so the delay is waited out before undeploying instead
+	if d.Get("deployment_strategy").(string) != DeploymentStrategyUndeployFirst {
+		requestForm["override"] = []string{strconv.FormatBool(true)}
+		if !c.IsGoogle() {
+			requestForm["delay"] = []string{strconv.Itoa(delay)}
+		}
 	}
 	if d.Get("service_account").(string) != "" {
 		if !c.IsGoogle() {
@@ -160,41 +165,198 @@ func resourceProxyDeploymentUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 		requestForm["serviceAccount"] = []string{d.Get("service_account").(string)}
 	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.FormEncoded},
-	}
-	_, err := c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, bytes.NewBufferString(requestForm.Encode()))
+	return deployProxyRevisionWithStrategy(ctx, c, d, envName, proxyName, revision, requestForm, d.Timeout(schema.TimeoutUpdate), nil)
+}
+
+func deployProxyRevisionWithStrategy(ctx context.Context, c *client.Client, d *schema.ResourceData, envName string, proxyName string, revision int, requestForm url.Values, timeout time.Duration, onDeployed func()) diag.Diagnostics {
+	var diags diag.Diagnostics
+	strategy := d.Get("deployment_strategy").(string)
+	//Check for base paths used by other proxies in the environment before changing anything
+	conflicts, err := findProxyBasePathConflicts(c, envName, proxyName, revision)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	//Force undeployment of previous revision, ignore errors if undeployed by override = true above
-	if prevRevision != 0 {
-		requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, prevRevision)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
-		if err != nil {
-			//Ignore errors
+	if len(conflicts) > 0 {
+		if strategy == DeploymentStrategyFailOnConflict {
+			return diag.Errorf("revision %d of proxy %s conflicts with existing deployments in environment %s:\n  %s", revision, proxyName, envName, strings.Join(conflicts, "\n  "))
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Revision %d of proxy %s conflicts with existing deployments in environment %s", revision, proxyName, envName),
+			Detail:   strings.Join(conflicts, "\n"),
+		})
+	}
+	//Read what is live now so revisions replaced by the deploy can be reported afterwards
+	previousRevisions, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	previousRevisions = removeRevision(previousRevisions, revision)
+	//Otherwise override=true swaps revisions in place, honoring the Edge delay, so nothing is undeployed here
+	if strategy == DeploymentStrategyUndeployFirst {
+		if len(previousRevisions) > 0 {
+			err = sleepContext(ctx, time.Duration(d.Get("delay").(int))*time.Second)
+			if err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+		for _, previousRevision := range previousRevisions {
+			//A revision that cannot be undeployed is reported, and the deploy below fails if it is still in the way
+			err = c.UndeployProxyRevision(envName, proxyName, previousRevision)
+			if err != nil {
+				diags = append(diags, undeployFailedWarning(proxyName, envName, previousRevision, err))
+			}
+		}
+	}
+	err = c.DeployProxyRevision(envName, proxyName, revision, requestForm)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if onDeployed != nil {
+		onDeployed()
+	}
+	err = waitForDeployment(ctx, timeout, func() (*client.DeploymentStatus, error) {
+		return c.GetProxyDeploymentStatus(envName, proxyName, revision)
+	})
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	//During an Edge delay the previous revision is still live
+	deployedRevisions, err := getProxyEnvironmentRevisions(c, envName, proxyName)
+	if err != nil {
+		deployedRevisions = []int{revision}
+	}
+	d.Set("deployed_revisions", deployedRevisions)
+	//Every strategy can replace previous revisions, so report whichever are no longer deployed
+	undeployed := []int{}
+	for _, previousRevision := range previousRevisions {
+		if _, found := findRevision(deployedRevisions, previousRevision); !found {
+			undeployed = append(undeployed, previousRevision)
 		}
 	}
+	if len(undeployed) > 0 {
+		diags = append(diags, undeployedWarning(proxyName, envName, undeployed))
+	}
 	return diags
 }
 
+func undeployedWarning(proxyName string, envName string, revisions []int) diag.Diagnostic {
+	revisionStrs := []string{}
+	for _, revision := range revisions {
+		revisionStrs = append(revisionStrs, strconv.Itoa(revision))
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Undeployed revisions of proxy %s from environment %s", proxyName, envName),
+		Detail:   "Undeployed revisions: " + strings.Join(revisionStrs, ", "),
+	}
+}
+
+func undeployFailedWarning(proxyName string, envName string, revision int, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Could not undeploy revision %d of proxy %s from environment %s", revision, proxyName, envName),
+		Detail:   err.Error(),
+	}
+}
+
+func removeRevision(revisions []int, revision int) []int {
+	retVal := []int{}
+	for _, r := range revisions {
+		if r != revision {
+			retVal = append(retVal, r)
+		}
+	}
+	return retVal
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(duration):
+		return nil
+	}
+}
+
+func getProxyEnvironmentRevisions(c *client.Client, envName string, proxyName string) ([]int, error) {
+	//All revisions of the proxy currently deployed to the environment, sorted numerically
+	deployedRevisions, err := getProxyEnvironmentDeployedRevisions(c, envName, proxyName)
+	if err != nil {
+		return nil, err
+	}
+	retVal := []int{}
+	for revision := range deployedRevisions {
+		retVal = append(retVal, revision)
+	}
+	sort.Ints(retVal)
+	return retVal, nil
+}
+
+func findProxyBasePathConflicts(c *client.Client, envName string, proxyName string, revision int) ([]string, error) {
+	retVal := []string{}
+	if c.IsGoogle() {
+		report, err := c.GenerateProxyDeployChangeReport(envName, proxyName, revision, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, conflict := range report.RoutingConflicts {
+			//Replacing a revision of the same proxy is not a conflict
+			if conflict.ConflictingDeployment.ProxyName == proxyName {
+				continue
+			}
+			retVal = append(retVal, conflict.Description)
+		}
+		return retVal, nil
+	}
+	//Edge has no change report so compare base paths with every other proxy deployed to the environment
+	//Base paths are cached by the client, so each revision is only fetched once per run
+	basePaths, err := c.GetProxyRevisionBasePaths(proxyName, revision)
+	if err != nil {
+		return nil, err
+	}
+	envDeployments, err := c.GetEnvironmentDeployments(envName, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range envDeployments.Deployments {
+		if dep.Name == proxyName {
+			continue
+		}
+		for _, rev := range dep.Revisions {
+			otherRevision, err := strconv.Atoi(rev.Name)
+			if err != nil {
+				return nil, err
+			}
+			otherBasePaths, err := c.GetProxyRevisionBasePaths(dep.Name, otherRevision)
+			if err != nil {
+				return nil, err
+			}
+			for _, basePath := range basePaths {
+				if _, found := find(otherBasePaths, basePath); found {
+					retVal = append(retVal, fmt.Sprintf("base path %s is already used by revision %d of proxy %s", basePath, otherRevision, dep.Name))
+				}
+			}
+		}
+	}
+	return retVal, nil
+}
+
 func resourceProxyDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	envName, proxyName := client.ProxyDeploymentDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Get all deployments of this proxy to this environment
-	requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentPath, c.Organization, envName, proxyName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	var envDeployments interface{}
+	var err error
 	if c.IsGoogle() {
-		envDeployments = &client.GoogleProxyEnvironmentDeployment{}
+		envDeployments, err = c.GetGoogleProxyEnvironmentDeployment(envName, proxyName)
 	} else {
-		envDeployments = &client.ProxyEnvironmentDeployment{}
+		envDeployments, err = c.GetProxyEnvironmentDeployment(envName, proxyName)
 	}
-	err = json.NewDecoder(body).Decode(envDeployments)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -214,8 +376,7 @@ func resourceProxyDeploymentDelete(ctx context.Context, d *schema.ResourceData,
 	}
 	//Delete each deployment
 	for _, revision := range deployedRevisions {
-		requestPath := fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, c.Organization, envName, proxyName, revision)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err := c.UndeployProxyRevision(envName, proxyName, revision)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -223,3 +384,40 @@ func resourceProxyDeploymentDelete(ctx context.Context, d *schema.ResourceData,
 	d.SetId("")
 	return diags
 }
+
+func getProxyEnvironmentDeployedRevisions(c *client.Client, envName string, proxyName string) (map[int]string, error) {
+	//Map each revision deployed to the environment to its service account, which is only set for Google
+	retVal := map[int]string{}
+	if c.IsGoogle() {
+		googleRetVal, err := c.GetGoogleProxyEnvironmentDeployment(envName, proxyName)
+		if err != nil {
+			if client.IsNotFound(err) {
+				return retVal, nil
+			}
+			return nil, err
+		}
+		for _, dep := range googleRetVal.Deployments {
+			revision, err := strconv.Atoi(dep.Revision)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = dep.ServiceAccount
+		}
+	} else {
+		oldRetVal, err := c.GetProxyEnvironmentDeployment(envName, proxyName)
+		if err != nil {
+			if client.IsNotFound(err) {
+				return retVal, nil
+			}
+			return nil, err
+		}
+		for _, rev := range oldRetVal.Revisions {
+			revision, err := strconv.Atoi(rev.Name)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = ""
+		}
+	}
+	return retVal, nil
+}
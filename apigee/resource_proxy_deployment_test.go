@@ -0,0 +1,104 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAccProxyDeployment(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		//Only Google runs proxies as a service account
+		serviceAccount := ""
+		if fake.google {
+			serviceAccount = `
+  service_account  = "acctest@testorg.iam.gserviceaccount.com"`
+		}
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_deployment", testAccProxyDeploymentPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_proxy" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_proxy_deployment" "test" {
+  proxy_name       = apigee_proxy.test.name
+  environment_name = "test"
+  revision         = apigee_proxy.test.revision`+serviceAccount+`
+}
+`, testAccFixture(t, "proxy")),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_proxy_deployment.test", testAccProxyDeploymentPath),
+						resource.TestCheckResourceAttr("apigee_proxy_deployment.test", "id", "test:acctest"),
+						resource.TestCheckResourceAttr("apigee_proxy_deployment.test", "deployed_revisions.#", "1"),
+						resource.TestCheckResourceAttr("apigee_proxy_deployment.test", "deployed_revisions.0", "1"),
+					),
+				},
+				{
+					ResourceName:            "apigee_proxy_deployment.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"delay"},
+				},
+			},
+		})
+	})
+}
+
+func TestDeployProxyRevisionReportsReplacedRevisions(t *testing.T) {
+	for _, strategy := range []string{DeploymentStrategyOverride, DeploymentStrategyUndeployFirst} {
+		strategy := strategy
+		t.Run(strategy, func(t *testing.T) {
+			testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+				c := fake.client(t)
+				previous := fake.addRevision("apis", "acctest", "/acctest")
+				revision := fake.addRevision("apis", "acctest", "/acctest")
+				err := c.DeployProxyRevision(testAccEnvironment, "acctest", previous, url.Values{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				d := schema.TestResourceDataRaw(t, resourceProxyDeployment().Schema, map[string]interface{}{
+					"proxy_name":          "acctest",
+					"environment_name":    testAccEnvironment,
+					"revision":            revision,
+					"deployment_strategy": strategy,
+				})
+				requestForm := url.Values{}
+				if strategy == DeploymentStrategyOverride {
+					requestForm["override"] = []string{"true"}
+				}
+				diags := deployProxyRevisionWithStrategy(context.Background(), c, d, testAccEnvironment, "acctest", revision, requestForm, time.Minute, nil)
+				if diags.HasError() {
+					t.Fatal(diags)
+				}
+				warned := false
+				for _, diagnostic := range diags {
+					if diagnostic.Summary == undeployedWarning("acctest", testAccEnvironment, nil).Summary {
+						warned = (diagnostic.Detail == "Undeployed revisions: "+strconv.Itoa(previous))
+					}
+				}
+				if !warned {
+					t.Errorf("expected revision %d to be reported as undeployed, got %v", previous, diags)
+				}
+			})
+		})
+	}
+}
+
+func testAccProxyDeploymentPath(rs *terraform.ResourceState) string {
+	envName, proxyName := client.ProxyDeploymentDecodeId(rs.Primary.ID)
+	revision, _ := strconv.Atoi(rs.Primary.Attributes["revision"])
+	return fmt.Sprintf(client.ProxyEnvironmentDeploymentRevisionPath, testAccOrganization, envName, proxyName, revision)
+}
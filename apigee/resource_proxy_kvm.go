@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceProxyKVM() *schema.Resource {
@@ -61,22 +56,12 @@ func resourceProxyKVM() *schema.Resource {
 func resourceProxyKVMCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newProxyKVM := client.KVM{
 		ProxyName: d.Get("proxy_name").(string),
 		Name:      d.Get("name").(string),
 	}
 	fillProxyKVM(&newProxyKVM, d)
-	err := json.NewEncoder(&buf).Encode(newProxyKVM)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProxyKVMPath, c.Organization, newProxyKVM.ProxyName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateProxyKVM(&newProxyKVM)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -111,22 +96,14 @@ func resourceProxyKVMRead(ctx context.Context, d *schema.ResourceData, m interfa
 	var diags diag.Diagnostics
 	proxyName, name := client.KVMDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyKVMPathGet, c.Organization, proxyName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetProxyKVM(proxyName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.KVM{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("proxy_name", proxyName)
 	d.Set("name", name)
 	d.Set("encrypted", retVal.Encrypted)
@@ -164,15 +141,11 @@ func resourceProxyKVMUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			continue
 		}
 		//Delete entry
-		requestPath := fmt.Sprintf(client.ProxyKVMPathEntriesGet, c.Organization, proxyName, name, oldKey)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err := c.DeleteProxyKVMEntry(proxyName, name, oldKey)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
 	//Public Apigee requires entries to be added/changed individually
 	if c.IsPublic() {
 		//Check for addition/modification of entries
@@ -186,41 +159,29 @@ func resourceProxyKVMUpdate(ctx context.Context, d *schema.ResourceData, m inter
 					continue
 				}
 			}
-			buf := bytes.Buffer{}
 			newOrModEntry := client.Attribute{
 				Name:  newKey,
 				Value: newOrModValue,
 			}
-			err := json.NewEncoder(&buf).Encode(newOrModEntry)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			requestPath := ""
+			var err error
 			if oldHasKey {
 				//Change entry
-				requestPath = fmt.Sprintf(client.ProxyKVMPathEntriesGet, c.Organization, proxyName, name, newKey)
+				err = c.UpdateProxyKVMEntry(proxyName, name, &newOrModEntry)
 			} else {
 				//Add entry
-				requestPath = fmt.Sprintf(client.ProxyKVMPathEntries, c.Organization, proxyName, name)
+				err = c.CreateProxyKVMEntry(proxyName, name, &newOrModEntry)
 			}
-			_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	} else {
-		buf := bytes.Buffer{}
 		upProxyKVM := client.KVM{
 			ProxyName: proxyName,
 			Name:      name,
 		}
 		fillProxyKVM(&upProxyKVM, d)
-		err := json.NewEncoder(&buf).Encode(upProxyKVM)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		requestPath := fmt.Sprintf(client.ProxyKVMPathGet, c.Organization, proxyName, name)
-		_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+		err := c.UpdateProxyKVM(&upProxyKVM)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -232,8 +193,7 @@ func resourceProxyKVMDelete(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 	proxyName, name := client.KVMDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyKVMPathGet, c.Organization, proxyName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteProxyKVM(proxyName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
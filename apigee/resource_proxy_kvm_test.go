@@ -0,0 +1,47 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccProxyKVM(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	fake.addRevision("apis", "acctest", "/acctest")
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_kvm", testAccProxyKVMPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_proxy_kvm" "test" {
+  proxy_name = "acctest"
+  name       = "acctest"
+  entry = {
+    key1 = "value1"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_proxy_kvm.test", testAccProxyKVMPath),
+					resource.TestCheckResourceAttr("apigee_proxy_kvm.test", "id", "acctest:acctest"),
+					resource.TestCheckResourceAttr("apigee_proxy_kvm.test", "entry.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      "apigee_proxy_kvm.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccProxyKVMPath(rs *terraform.ResourceState) string {
+	proxyName, name := client.KVMDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.ProxyKVMPathGet, testAccOrganization, proxyName, name)
+}
@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceProxyPolicy() *schema.Resource {
@@ -60,18 +55,7 @@ func resourceProxyPolicyCreate(ctx context.Context, d *schema.ResourceData, m in
 		Revision:  d.Get("revision").(int),
 		Name:      d.Get("name").(string),
 	}
-	file := d.Get("file").(string)
-	//Turn filename into buffer
-	buf, err := client.GetBuffer(file)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProxyPolicyPath, c.Organization, newProxyPolicy.ProxyName, newProxyPolicy.Revision)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationXml},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, buf)
+	err := c.CreateProxyPolicy(&newProxyPolicy, d.Get("file").(string))
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -85,28 +69,14 @@ func resourceProxyPolicyRead(ctx context.Context, d *schema.ResourceData, m inte
 	proxyName, rev, name := client.ProxyPolicyDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Reading specific file returns actual contents of file so read all policies and search for name instead
-	requestPath := fmt.Sprintf(client.ProxyPolicyPath, c.Organization, proxyName, rev)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListProxyPolicies(proxyName, rev)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	respBody := new(bytes.Buffer)
-	_, err = respBody.ReadFrom(body)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	retVal := []string{}
-	err = json.Unmarshal(respBody.Bytes(), &retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	//Look for existence of name
 	found := false
 	for _, file := range retVal {
@@ -129,8 +99,7 @@ func resourceProxyPolicyDelete(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	proxyName, rev, name := client.ProxyPolicyDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyPolicyPathGet, c.Organization, proxyName, rev, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteProxyPolicy(proxyName, rev, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,51 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccProxyPolicy(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_policy", testAccProxyPolicyPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_proxy" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_proxy_policy" "test" {
+  proxy_name = apigee_proxy.test.name
+  revision   = apigee_proxy.test.revision
+  name       = "AddHeader"
+  file       = %q
+  file_hash  = "1"
+}
+`, testAccFixture(t, "proxy"), testAccFixture(t, "policy.xml")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_proxy_policy.test", testAccProxyPolicyPath),
+					resource.TestCheckResourceAttr("apigee_proxy_policy.test", "id", "acctest:1:AddHeader"),
+				),
+			},
+			{
+				ResourceName:            "apigee_proxy_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccProxyPolicyPath(rs *terraform.ResourceState) string {
+	proxyName, revision, name := client.ProxyPolicyDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.ProxyPolicyPathGet, testAccOrganization, proxyName, revision, name)
+}
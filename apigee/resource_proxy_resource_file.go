@@ -1,17 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 )
 
 func resourceProxyResourceFile() *schema.Resource {
@@ -66,24 +60,7 @@ func resourceProxyResourceFileCreate(ctx context.Context, d *schema.ResourceData
 		Type:      d.Get("type").(string),
 		Name:      d.Get("name").(string),
 	}
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProxyResourceFilePath, c.Organization, newProxyResourceFile.ProxyName, newProxyResourceFile.Revision)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
-	}
-	requestQuery := url.Values{
-		"type": []string{newProxyResourceFile.Type},
-		"name": []string{newProxyResourceFile.Name},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err := c.CreateProxyResourceFile(&newProxyResourceFile, d.Get("file").(string))
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -97,25 +74,17 @@ func resourceProxyResourceFileRead(ctx context.Context, d *schema.ResourceData,
 	proxyName, rev, rtype, name := client.ProxyResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Reading specific file returns actual contents of file so read all files of type and search for name instead
-	requestPath := fmt.Sprintf(client.ProxyResourceFilePathOfType, c.Organization, proxyName, rev, rtype)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.ListProxyResourceFilesOfType(proxyName, rev, rtype)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.ResourceFilesOfType{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	//Look for existence of name
 	found := false
-	for _, file := range retVal.Files {
+	for _, file := range retVal {
 		if file.Name == name {
 			found = true
 			break
@@ -136,19 +105,13 @@ func resourceProxyResourceFileUpdate(ctx context.Context, d *schema.ResourceData
 	var diags diag.Diagnostics
 	proxyName, rev, rtype, name := client.ProxyResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	file := d.Get("file").(string)
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"file": client.FormData{Filename: file},
-	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ProxyResourceFilePathGet, c.Organization, proxyName, rev, rtype, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
+	upProxyResourceFile := client.ResourceFile{
+		ProxyName: proxyName,
+		Revision:  rev,
+		Type:      rtype,
+		Name:      name,
 	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, buf)
+	err := c.UpdateProxyResourceFile(&upProxyResourceFile, d.Get("file").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -159,8 +122,7 @@ func resourceProxyResourceFileDelete(ctx context.Context, d *schema.ResourceData
 	var diags diag.Diagnostics
 	proxyName, rev, rtype, name := client.ProxyResourceFileDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ProxyResourceFilePathGet, c.Organization, proxyName, rev, rtype, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteProxyResourceFile(proxyName, rev, rtype, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,52 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccProxyResourceFile(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy_resource_file", testAccProxyResourceFilePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_proxy" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_proxy_resource_file" "test" {
+  proxy_name = apigee_proxy.test.name
+  revision   = apigee_proxy.test.revision
+  type       = "jsc"
+  name       = "acctest.js"
+  file       = %q
+  file_hash  = "1"
+}
+`, testAccFixture(t, "proxy"), testAccFixture(t, "resource.js")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_proxy_resource_file.test", testAccProxyResourceFilePath),
+					resource.TestCheckResourceAttr("apigee_proxy_resource_file.test", "id", "acctest:1:jsc:acctest.js"),
+				),
+			},
+			{
+				ResourceName:            "apigee_proxy_resource_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccProxyResourceFilePath(rs *terraform.ResourceState) string {
+	proxyName, revision, rtype, name := client.ProxyResourceFileDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.ProxyResourceFilePathGet, testAccOrganization, proxyName, revision, rtype, name)
+}
@@ -0,0 +1,48 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccProxy(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_proxy", testAccProxyPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_proxy" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+`, testAccFixture(t, "proxy")),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_proxy.test", testAccProxyPath),
+						resource.TestCheckResourceAttr("apigee_proxy.test", "id", "acctest"),
+						resource.TestCheckResourceAttr("apigee_proxy.test", "revision", "1"),
+						resource.TestCheckResourceAttr("apigee_proxy.test", "base_paths.0", "/acctest"),
+						resource.TestCheckResourceAttr("apigee_proxy.test", "proxy_endpoints.0", "default"),
+						resource.TestCheckResourceAttrSet("apigee_proxy.test", "bundle_hash"),
+					),
+				},
+				{
+					ResourceName:      "apigee_proxy.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+					//The bundle and how to manage it only live in the configuration
+					ImportStateVerifyIgnore: []string{"bundle", "bundle_dir", "bundle_hash", "keep_revisions", "delete_behavior", "skip_bundle_validation"},
+				},
+			},
+		})
+	})
+}
+
+func testAccProxyPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.ProxyPathGet, testAccOrganization, rs.Primary.ID)
+}
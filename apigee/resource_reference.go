@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 )
 
 func resourceReference() *schema.Resource {
@@ -50,23 +45,13 @@ func resourceReference() *schema.Resource {
 func resourceReferenceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	newReference := client.Reference{
 		EnvironmentName: d.Get("environment_name").(string),
 		Name:            d.Get("name").(string),
 		Refers:          d.Get("refers").(string),
 		ResourceType:    d.Get("resource_type").(string),
 	}
-	err := json.NewEncoder(&buf).Encode(newReference)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ReferencePath, c.Organization, newReference.EnvironmentName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateReference(&newReference)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -79,22 +64,14 @@ func resourceReferenceRead(ctx context.Context, d *schema.ResourceData, m interf
 	var diags diag.Diagnostics
 	envName, name := client.ReferenceDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ReferencePathGet, c.Organization, envName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetReference(envName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.Reference{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("environment_name", envName)
 	d.Set("name", name)
 	d.Set("refers", retVal.Refers)
@@ -106,22 +83,13 @@ func resourceReferenceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	envName, name := client.ReferenceDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	upReference := client.Reference{
 		EnvironmentName: envName,
 		Name:            name,
 		Refers:          d.Get("refers").(string),
 		ResourceType:    d.Get("resource_type").(string),
 	}
-	err := json.NewEncoder(&buf).Encode(upReference)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.ReferencePathGet, c.Organization, envName, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateReference(&upReference)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -132,8 +100,7 @@ func resourceReferenceDelete(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	envName, name := client.ReferenceDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.ReferencePathGet, c.Organization, envName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteReference(envName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,50 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccReference(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_reference", testAccReferencePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_keystore" "test" {
+  environment_name = "test"
+  name             = "acctest"
+}
+
+resource "apigee_reference" "test" {
+  environment_name = "test"
+  name             = "acctest"
+  refers           = apigee_keystore.test.name
+  resource_type    = "KeyStore"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_reference.test", testAccReferencePath),
+					resource.TestCheckResourceAttr("apigee_reference.test", "id", "test:acctest"),
+					resource.TestCheckResourceAttr("apigee_reference.test", "refers", "acctest"),
+				),
+			},
+			{
+				ResourceName:      "apigee_reference.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccReferencePath(rs *terraform.ResourceState) string {
+	envName, name := client.ReferenceDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.ReferencePathGet, testAccOrganization, envName, name)
+}
@@ -1,17 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 )
 
 func resourceRolePermission() *schema.Resource {
@@ -49,7 +43,6 @@ func resourceRolePermission() *schema.Resource {
 func resourceRolePermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	permSet := d.Get("permissions").(*schema.Set)
 	permList := convertSetToArray(permSet)
 	newRolePermission := client.RolePermission{
@@ -57,16 +50,7 @@ func resourceRolePermissionCreate(ctx context.Context, d *schema.ResourceData, m
 		Path:        d.Get("path").(string),
 		Permissions: permList,
 	}
-	err := json.NewEncoder(&buf).Encode(newRolePermission)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.RolePermissionPath, c.Organization, newRolePermission.RoleName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateRolePermission(&newRolePermission)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -79,25 +63,14 @@ func resourceRolePermissionRead(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	roleName, path := client.RolePermissionDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.RolePermissionPath, c.Organization, roleName)
-	requestQuery := url.Values{
-		"path": []string{path},
-	}
-	body, err := c.HttpRequest(http.MethodGet, requestPath, requestQuery, nil, &bytes.Buffer{})
+	retVal, err := c.GetRolePermission(roleName, path)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.RolePermission{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("role_name", roleName)
 	d.Set("path", path)
 	d.Set("permissions", retVal.Permissions)
@@ -108,18 +81,11 @@ func resourceRolePermissionDelete(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	roleName, path := client.RolePermissionDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestQuery := url.Values{
-		"path": []string{path},
-	}
 	//Remove each perm
 	for _, p := range []string{"get", "put", "delete"} {
-		requestPath := fmt.Sprintf(client.RolePermissionPathGet, c.Organization, roleName, p)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, requestQuery, nil, &bytes.Buffer{})
-		if err != nil {
-			re := err.(*client.RequestError)
-			if re.StatusCode != http.StatusNotFound {
-				return diag.FromErr(err)
-			}
+		err := c.DeleteRolePermission(roleName, path, p)
+		if (err != nil) && !client.IsNotFound(err) {
+			return diag.FromErr(err)
 		}
 	}
 	d.SetId("")
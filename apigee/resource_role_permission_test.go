@@ -0,0 +1,49 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccRolePermission(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_role_permission", testAccRolePermissionPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_role" "test" {
+  name = "acctest"
+}
+
+resource "apigee_role_permission" "test" {
+  role_name   = apigee_role.test.name
+  path        = "/environments"
+  permissions = ["get", "put"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_role_permission.test", testAccRolePermissionPath),
+					resource.TestCheckResourceAttr("apigee_role_permission.test", "id", "acctest:/environments"),
+					resource.TestCheckResourceAttr("apigee_role_permission.test", "permissions.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "apigee_role_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRolePermissionPath(rs *terraform.ResourceState) string {
+	//The fake keeps permissions under the role, keyed by the path they apply to
+	roleName, path := client.RolePermissionDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.RolePermissionPath, testAccOrganization, roleName) + path
+}
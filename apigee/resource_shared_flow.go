@@ -1,17 +1,13 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 	"strconv"
+	"strings"
 )
 
 func resourceSharedFlow() *schema.Resource {
@@ -30,12 +26,35 @@ func resourceSharedFlow() *schema.Resource {
 				ForceNew: true,
 			},
 			"bundle": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"bundle", "bundle_dir"},
+				RequiredWith: []string{"bundle_hash"},
+			},
+			"bundle_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"bundle", "bundle_dir"},
 			},
 			"bundle_hash": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"bundle_dir"},
+			},
+			"keep_revisions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"delete_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{DeleteBehaviorSkipIfDeployed, DeleteBehaviorUndeployAndDelete, DeleteBehaviorFailIfDeployed}, false),
+			},
+			"skip_bundle_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 			"revision": {
 				Type:     schema.TypeInt,
@@ -47,50 +66,31 @@ func resourceSharedFlow() *schema.Resource {
 }
 
 func resourceSharedFlowCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
-	//Mark the revision as changing if bundle changes
-	if diff.HasChange("bundle") {
-		diff.SetNewComputed("revision")
-	}
-	if diff.HasChange("bundle_hash") {
-		diff.SetNewComputed("revision")
-	}
-	return nil
-}
-
-func importSharedFlowRevision(c *client.Client, name string, bundle string) (*client.SharedFlowRevision, error) {
-	//Turn filename into multi part buffer
-	mp, buf, err := client.GetMultiPartBuffer(map[string]client.FormData{
-		"bundle": client.FormData{Filename: bundle},
-	})
+	err := customizeBundleDirDiff(diff, client.SharedFlowBundleRoot)
 	if err != nil {
-		return nil, err
-	}
-	requestPath := fmt.Sprintf(client.SharedFlowPath, c.Organization)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{mp.FormDataContentType()},
+		return err
 	}
-	requestQuery := url.Values{
-		"action": []string{"import"},
-		"name":   []string{name},
-	}
-	body, err := c.HttpRequest(http.MethodPost, requestPath, requestQuery, requestHeaders, buf)
+	err = validateBundleDiff(diff, client.SharedFlowBundleRoot)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	retVal := &client.SharedFlowRevision{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		return nil, err
+	//Mark the revision as changing if bundle changes
+	if bundleChanged(diff) {
+		diff.SetNewComputed("revision")
 	}
-	return retVal, nil
+	return nil
 }
 
 func resourceSharedFlowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
 	name := d.Get("name").(string)
-	bundle := d.Get("bundle").(string)
-	retVal, err := importSharedFlowRevision(c, name, bundle)
+	bundle, err := getBundleFormData(d, client.SharedFlowBundleRoot)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal, err := c.ImportSharedFlow(name, bundle)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -98,28 +98,21 @@ func resourceSharedFlowCreate(ctx context.Context, d *schema.ResourceData, m int
 	d.SetId(name)
 	revision, _ := strconv.Atoi(retVal.Revision)
 	d.Set("revision", revision)
+	diags = append(diags, pruneSharedFlowRevisions(c, d)...)
 	return diags
 }
 
 func resourceSharedFlowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.SharedFlowPathGet, c.Organization, d.Id())
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetSharedFlow(d.Id())
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.SharedFlow{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("name", d.Id())
 	//Empirically the revisions array is sorted *alphabetically* when we get
 	//it which means that, for example, an API with 10 revisions comes back
@@ -147,24 +140,133 @@ func resourceSharedFlowRead(ctx context.Context, d *schema.ResourceData, m inter
 func resourceSharedFlowUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	bundle := d.Get("bundle").(string)
-	retVal, err := importSharedFlowRevision(c, d.Id(), bundle)
-	if err != nil {
-		return diag.FromErr(err)
+	//Only import a new revision if the bundle itself changed
+	if bundleChanged(d) {
+		bundle, err := getBundleFormData(d, client.SharedFlowBundleRoot)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		retVal, err := c.ImportSharedFlow(d.Id(), bundle)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		revision, _ := strconv.Atoi(retVal.Revision)
+		d.Set("revision", revision)
 	}
-	revision, _ := strconv.Atoi(retVal.Revision)
-	d.Set("revision", revision)
+	diags = append(diags, pruneSharedFlowRevisions(c, d)...)
 	return diags
 }
 
 func resourceSharedFlowDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.SharedFlowPathGet, c.Organization, d.Id())
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	//Get all deployments of this shared flow to ANY environment
+	deployedRevisions, err := getSharedFlowDeployedRevisions(c, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(deployedRevisions) > 0 {
+		deleteBehavior := d.Get("delete_behavior").(string)
+		if deleteBehavior == "" {
+			deleteBehavior = DeleteBehaviorSkipIfDeployed
+		}
+		envNames := strings.Join(deployedEnvironments(deployedRevisions), ", ")
+		switch deleteBehavior {
+		case DeleteBehaviorSkipIfDeployed:
+			//Assume that the deployments belong to a different TF configuration which will handle the delete,
+			//so report deletion to TF even though it was not deleted from Apigee
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Shared flow " + d.Id() + " was not deleted because it is deployed",
+				Detail:   "Deployed to environments: " + envNames,
+			})
+			d.SetId("")
+			return diags
+		case DeleteBehaviorFailIfDeployed:
+			return diag.Errorf("shared flow %s cannot be deleted because it is deployed to environments: %s", d.Id(), envNames)
+		case DeleteBehaviorUndeployAndDelete:
+			for revision, envs := range deployedRevisions {
+				for _, envName := range envs {
+					err := c.UndeploySharedFlowRevision(envName, d.Id(), revision)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
+	}
+	err = c.DeleteSharedFlow(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return diags
 }
+
+func getSharedFlowDeployedRevisions(c *client.Client, name string) (map[int][]string, error) {
+	//Map each deployed revision to the environments it is deployed to
+	retVal := map[int][]string{}
+	if c.IsGoogle() {
+		googleDeployments, err := c.GetGoogleSharedFlowDeployments(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range googleDeployments.Deployments {
+			revision, err := strconv.Atoi(dep.Revision)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = append(retVal[revision], dep.EnvironmentName)
+		}
+	} else {
+		oldDeployments, err := c.GetSharedFlowDeployments(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, env := range oldDeployments.Environments {
+			for _, rev := range env.Revisions {
+				revision, err := strconv.Atoi(rev.Name)
+				if err != nil {
+					return nil, err
+				}
+				retVal[revision] = append(retVal[revision], env.EnvironmentName)
+			}
+		}
+	}
+	return retVal, nil
+}
+
+func pruneSharedFlowRevisions(c *client.Client, d *schema.ResourceData) diag.Diagnostics {
+	//The import already succeeded so failing to prune is only reported as a warning
+	var diags diag.Diagnostics
+	keepRevisions := d.Get("keep_revisions").(int)
+	if keepRevisions == 0 {
+		return diags
+	}
+	sharedFlow, err := c.GetSharedFlow(d.Id())
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	revisions, err := sortRevisions(sharedFlow.Revisions)
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	if len(revisions) <= keepRevisions {
+		return diags
+	}
+	//Never delete a revision deployed to ANY environment, even if it is older than the newest N
+	deployedRevisions, err := getSharedFlowDeployedRevisions(c, d.Id())
+	if err != nil {
+		return append(diags, pruneWarning(d.Id(), err))
+	}
+	for _, revision := range revisions[:len(revisions)-keepRevisions] {
+		if _, deployed := deployedRevisions[revision]; deployed {
+			continue
+		}
+		err = c.DeleteSharedFlowRevision(d.Id(), revision)
+		if err != nil {
+			return append(diags, pruneWarning(d.Id(), err))
+		}
+	}
+	return diags
+}
@@ -1,17 +1,13 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -25,6 +21,11 @@ func resourceSharedFlowDeployment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceSharedFlowDeploymentCustomDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultDeploymentTimeout),
+			Update: schema.DefaultTimeout(DefaultDeploymentTimeout),
+		},
 		Schema: map[string]*schema.Schema{
 			"shared_flow_name": {
 				Type:     schema.TypeString,
@@ -51,6 +52,11 @@ func resourceSharedFlowDeployment() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"deployed_revisions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
 		},
 	}
 }
@@ -60,10 +66,18 @@ func resourceSharedFlowDelayDiff(k string, old string, n string, d *schema.Resou
 	return true
 }
 
+func resourceSharedFlowDeploymentCustomDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	//Deploying a different revision replaces whatever is live
+	if diff.HasChange("revision") {
+		diff.SetNewComputed("deployed_revisions")
+	}
+	return nil
+}
+
 func resourceSharedFlowDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	v := url.Values{}
+	requestForm := url.Values{}
 	newSharedFlowDeployment := client.SharedFlowDeployment{
 		EnvironmentName: d.Get("environment_name").(string),
 		SharedFlowName:  d.Get("shared_flow_name").(string),
@@ -73,16 +87,27 @@ func resourceSharedFlowDeploymentCreate(ctx context.Context, d *schema.ResourceD
 			return diag.Errorf("service_account cannot be set for non-Google Cloud Apigee versions")
 		}
 		newSharedFlowDeployment.ServiceAccount = d.Get("service_account").(string)
-		v.Set("serviceAccount", newSharedFlowDeployment.ServiceAccount)
+		requestForm.Set("serviceAccount", newSharedFlowDeployment.ServiceAccount)
 	}
 	revision := d.Get("revision").(int)
-	requestPath := fmt.Sprintf(client.SharedFlowDeploymentRevisionPath, c.Organization, newSharedFlowDeployment.EnvironmentName, newSharedFlowDeployment.SharedFlowName, revision)
-	_, err := c.HttpRequest(http.MethodPost, requestPath, v, nil, &bytes.Buffer{})
+	err := c.DeploySharedFlowRevision(newSharedFlowDeployment.EnvironmentName, newSharedFlowDeployment.SharedFlowName, revision, requestForm)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
 	}
 	d.SetId(newSharedFlowDeployment.SharedFlowDeploymentEncodeId())
+	err = waitForDeployment(ctx, d.Timeout(schema.TimeoutCreate), func() (*client.DeploymentStatus, error) {
+		return c.GetSharedFlowDeploymentStatus(newSharedFlowDeployment.EnvironmentName, newSharedFlowDeployment.SharedFlowName, revision)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	//During an Edge delay the previous revision is still live
+	deployedRevisions, err := getSharedFlowEnvironmentRevisions(c, newSharedFlowDeployment.EnvironmentName, newSharedFlowDeployment.SharedFlowName)
+	if err != nil {
+		deployedRevisions = []int{revision}
+	}
+	d.Set("deployed_revisions", deployedRevisions)
 	return diags
 }
 
@@ -90,48 +115,32 @@ func resourceSharedFlowDeploymentRead(ctx context.Context, d *schema.ResourceDat
 	var diags diag.Diagnostics
 	envName, sharedFlowName := client.SharedFlowDeploymentDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.SharedFlowDeploymentPath, c.Organization, envName, sharedFlowName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	deployedRevisions, err := getSharedFlowEnvironmentDeployedRevisions(c, envName, sharedFlowName)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
-			return diags
-		}
 		return diag.FromErr(err)
 	}
-	var retVal interface{}
-	if c.IsGoogle() {
-		retVal = &client.GoogleSharedFlowDeployment{}
-	} else {
-		retVal = &client.SharedFlowDeployment{}
-	}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
+	//Nothing deployed means the deployment is gone and must be recreated
+	if len(deployedRevisions) == 0 {
 		d.SetId("")
-		return diag.FromErr(err)
+		return diags
 	}
 	d.Set("environment_name", envName)
 	d.Set("shared_flow_name", sharedFlowName)
-	lastRevision := ""
-	//Retrieve the latest revision deployed as THE revision, assumes array is sorted
-	if c.IsGoogle() {
-		googleRetVal := retVal.(*client.GoogleSharedFlowDeployment)
-		lastRevision = googleRetVal.Deployments[len(googleRetVal.Deployments)-1].Revision
-	} else {
-		oldRetVal := retVal.(*client.SharedFlowDeployment)
-		lastRevision = oldRetVal.Revisions[len(oldRetVal.Revisions)-1].Name
+	revisions := []int{}
+	for revision := range deployedRevisions {
+		revisions = append(revisions, revision)
 	}
-	revision, _ := strconv.Atoi(lastRevision)
-	d.Set("revision", revision)
-	if c.IsGoogle() {
-		googleRetVal := retVal.(*client.GoogleSharedFlowDeployment)
-		serviceAccount := googleRetVal.Deployments[len(googleRetVal.Deployments)-1].ServiceAccount
-		//When reading the service account, it is prefixed by "projects/-/serviceAccounts/"
-		d.Set("service_account", strings.TrimPrefix(serviceAccount, "projects/-/serviceAccounts/"))
-	} else {
-		d.Set("service_account", "")
+	sort.Ints(revisions)
+	//During a rollout more than one revision can be live, so keep the configured revision while it is one of them, otherwise the highest one is THE revision
+	revision := d.Get("revision").(int)
+	if _, found := deployedRevisions[revision]; !found {
+		revision = revisions[len(revisions)-1]
 	}
+	d.Set("revision", revision)
+	d.Set("deployed_revisions", revisions)
+	//When reading the service account, it is prefixed by "projects/-/serviceAccounts/"
+	d.Set("service_account", strings.TrimPrefix(deployedRevisions[revision], client.GoogleServiceAccountPrefix))
 	return diags
 }
 
@@ -141,7 +150,6 @@ func resourceSharedFlowDeploymentUpdate(ctx context.Context, d *schema.ResourceD
 	c := m.(*client.Client)
 	revision := d.Get("revision").(int)
 	delay := d.Get("delay").(int)
-	requestPath := fmt.Sprintf(client.SharedFlowDeploymentRevisionPath, c.Organization, envName, sharedFlowName, revision)
 	requestForm := url.Values{
 		"override": []string{strconv.FormatBool(true)},
 	}
@@ -154,13 +162,22 @@ func resourceSharedFlowDeploymentUpdate(ctx context.Context, d *schema.ResourceD
 		}
 		requestForm["serviceAccount"] = []string{d.Get("service_account").(string)}
 	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.FormEncoded},
+	err := c.DeploySharedFlowRevision(envName, sharedFlowName, revision, requestForm)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	_, err := c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, bytes.NewBufferString(requestForm.Encode()))
+	err = waitForDeployment(ctx, d.Timeout(schema.TimeoutUpdate), func() (*client.DeploymentStatus, error) {
+		return c.GetSharedFlowDeploymentStatus(envName, sharedFlowName, revision)
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	//During an Edge delay the previous revision is still live
+	deployedRevisions, err := getSharedFlowEnvironmentRevisions(c, envName, sharedFlowName)
+	if err != nil {
+		deployedRevisions = []int{revision}
+	}
+	d.Set("deployed_revisions", deployedRevisions)
 	return diags
 }
 
@@ -169,18 +186,13 @@ func resourceSharedFlowDeploymentDelete(ctx context.Context, d *schema.ResourceD
 	envName, sharedFlowName := client.SharedFlowDeploymentDecodeId(d.Id())
 	c := m.(*client.Client)
 	//Get all deployments of this shared flow to this environment
-	requestPath := fmt.Sprintf(client.SharedFlowDeploymentPath, c.Organization, envName, sharedFlowName)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	var envDeployments interface{}
+	var err error
 	if c.IsGoogle() {
-		envDeployments = &client.GoogleSharedFlowDeployment{}
+		envDeployments, err = c.GetGoogleSharedFlowEnvironmentDeployment(envName, sharedFlowName)
 	} else {
-		envDeployments = &client.SharedFlowDeployment{}
+		envDeployments, err = c.GetSharedFlowEnvironmentDeployment(envName, sharedFlowName)
 	}
-	err = json.NewDecoder(body).Decode(envDeployments)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -200,8 +212,7 @@ func resourceSharedFlowDeploymentDelete(ctx context.Context, d *schema.ResourceD
 	}
 	//Delete each deployment
 	for _, revision := range deployedRevisions {
-		requestPath := fmt.Sprintf(client.SharedFlowDeploymentRevisionPath, c.Organization, envName, sharedFlowName, revision)
-		_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+		err := c.UndeploySharedFlowRevision(envName, sharedFlowName, revision)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -209,3 +220,54 @@ func resourceSharedFlowDeploymentDelete(ctx context.Context, d *schema.ResourceD
 	d.SetId("")
 	return diags
 }
+
+func getSharedFlowEnvironmentRevisions(c *client.Client, envName string, sharedFlowName string) ([]int, error) {
+	//All revisions of the shared flow currently deployed to the environment, sorted numerically
+	deployedRevisions, err := getSharedFlowEnvironmentDeployedRevisions(c, envName, sharedFlowName)
+	if err != nil {
+		return nil, err
+	}
+	retVal := []int{}
+	for revision := range deployedRevisions {
+		retVal = append(retVal, revision)
+	}
+	sort.Ints(retVal)
+	return retVal, nil
+}
+
+func getSharedFlowEnvironmentDeployedRevisions(c *client.Client, envName string, sharedFlowName string) (map[int]string, error) {
+	//Map each revision deployed to the environment to its service account, which is only set for Google
+	retVal := map[int]string{}
+	if c.IsGoogle() {
+		googleRetVal, err := c.GetGoogleSharedFlowEnvironmentDeployment(envName, sharedFlowName)
+		if err != nil {
+			if client.IsNotFound(err) {
+				return retVal, nil
+			}
+			return nil, err
+		}
+		for _, dep := range googleRetVal.Deployments {
+			revision, err := strconv.Atoi(dep.Revision)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = dep.ServiceAccount
+		}
+	} else {
+		oldRetVal, err := c.GetSharedFlowEnvironmentDeployment(envName, sharedFlowName)
+		if err != nil {
+			if client.IsNotFound(err) {
+				return retVal, nil
+			}
+			return nil, err
+		}
+		for _, rev := range oldRetVal.Revisions {
+			revision, err := strconv.Atoi(rev.Name)
+			if err != nil {
+				return nil, err
+			}
+			retVal[revision] = ""
+		}
+	}
+	return retVal, nil
+}
@@ -0,0 +1,81 @@
+package apigee
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestAccSharedFlowDeployment(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_shared_flow_deployment", testAccSharedFlowDeploymentPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_shared_flow" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_shared_flow_deployment" "test" {
+  shared_flow_name = apigee_shared_flow.test.name
+  environment_name = "test"
+  revision         = apigee_shared_flow.test.revision
+}
+`, testAccFixture(t, "shared_flow")),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_shared_flow_deployment.test", testAccSharedFlowDeploymentPath),
+						resource.TestCheckResourceAttr("apigee_shared_flow_deployment.test", "id", "test:acctest"),
+						resource.TestCheckResourceAttr("apigee_shared_flow_deployment.test", "deployed_revisions.#", "1"),
+					),
+				},
+				{
+					ResourceName:            "apigee_shared_flow_deployment.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"delay"},
+				},
+			},
+		})
+	})
+}
+
+func TestSharedFlowDeploymentCreateReadsBackDeployedRevisions(t *testing.T) {
+	//Edge deploys alongside the previous revision when override is not set, so both are live afterwards
+	fake := newFakeApigee(t, false)
+	c := fake.client(t)
+	previous := fake.addRevision("sharedflows", "acctest")
+	revision := fake.addRevision("sharedflows", "acctest")
+	err := c.DeploySharedFlowRevision(testAccEnvironment, "acctest", previous, url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceSharedFlowDeployment().Schema, map[string]interface{}{
+		"shared_flow_name": "acctest",
+		"environment_name": testAccEnvironment,
+		"revision":         revision,
+	})
+	diags := resourceSharedFlowDeploymentCreate(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	got := d.Get("deployed_revisions").([]interface{})
+	if (len(got) != 2) || (got[0].(int) != previous) || (got[1].(int) != revision) {
+		t.Errorf("expected deployed revisions [%d %d], got %v", previous, revision, got)
+	}
+}
+
+func testAccSharedFlowDeploymentPath(rs *terraform.ResourceState) string {
+	envName, sharedFlowName := client.SharedFlowDeploymentDecodeId(rs.Primary.ID)
+	revision, _ := strconv.Atoi(rs.Primary.Attributes["revision"])
+	return fmt.Sprintf(client.SharedFlowDeploymentRevisionPath, testAccOrganization, envName, sharedFlowName, revision)
+}
@@ -0,0 +1,108 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceSharedFlowPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSharedFlowPolicyCreate,
+		ReadContext:   resourceSharedFlowPolicyRead,
+		DeleteContext: resourceSharedFlowPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"shared_flow_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"revision": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file_hash": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceSharedFlowPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newSharedFlowPolicy := client.SharedFlowPolicy{
+		SharedFlowName: d.Get("shared_flow_name").(string),
+		Revision:       d.Get("revision").(int),
+		Name:           d.Get("name").(string),
+	}
+	err := c.CreateSharedFlowPolicy(&newSharedFlowPolicy, d.Get("file").(string))
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newSharedFlowPolicy.SharedFlowPolicyEncodeId())
+	return diags
+}
+
+func resourceSharedFlowPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sharedFlowName, rev, name := client.SharedFlowPolicyDecodeId(d.Id())
+	c := m.(*client.Client)
+	//Reading specific file returns actual contents of file so read all policies and search for name instead
+	retVal, err := c.ListSharedFlowPolicies(sharedFlowName, rev)
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	//Look for existence of name
+	found := false
+	for _, file := range retVal {
+		if file == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		d.SetId("")
+		return diags
+	}
+	d.Set("shared_flow_name", sharedFlowName)
+	d.Set("revision", rev)
+	d.Set("name", name)
+	return diags
+}
+
+func resourceSharedFlowPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sharedFlowName, rev, name := client.SharedFlowPolicyDecodeId(d.Id())
+	c := m.(*client.Client)
+	err := c.DeleteSharedFlowPolicy(sharedFlowName, rev, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,51 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccSharedFlowPolicy(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_shared_flow_policy", testAccSharedFlowPolicyPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_shared_flow" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_shared_flow_policy" "test" {
+  shared_flow_name = apigee_shared_flow.test.name
+  revision         = apigee_shared_flow.test.revision
+  name             = "AddHeader"
+  file             = %q
+  file_hash        = "1"
+}
+`, testAccFixture(t, "shared_flow"), testAccFixture(t, "policy.xml")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_shared_flow_policy.test", testAccSharedFlowPolicyPath),
+					resource.TestCheckResourceAttr("apigee_shared_flow_policy.test", "id", "acctest:1:AddHeader"),
+				),
+			},
+			{
+				ResourceName:            "apigee_shared_flow_policy.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccSharedFlowPolicyPath(rs *terraform.ResourceState) string {
+	sharedFlowName, revision, name := client.SharedFlowPolicyDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.SharedFlowPolicyPathGet, testAccOrganization, sharedFlowName, revision, name)
+}
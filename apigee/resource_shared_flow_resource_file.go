@@ -0,0 +1,131 @@
+package apigee
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+)
+
+func resourceSharedFlowResourceFile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSharedFlowResourceFileCreate,
+		ReadContext:   resourceSharedFlowResourceFileRead,
+		UpdateContext: resourceSharedFlowResourceFileUpdate,
+		DeleteContext: resourceSharedFlowResourceFileDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"shared_flow_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"revision": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"file_hash": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceSharedFlowResourceFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newSharedFlowResourceFile := client.ResourceFile{
+		SharedFlowName: d.Get("shared_flow_name").(string),
+		Revision:       d.Get("revision").(int),
+		Type:           d.Get("type").(string),
+		Name:           d.Get("name").(string),
+	}
+	err := c.CreateSharedFlowResourceFile(&newSharedFlowResourceFile, d.Get("file").(string))
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(newSharedFlowResourceFile.SharedFlowResourceFileEncodeId())
+	return diags
+}
+
+func resourceSharedFlowResourceFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sharedFlowName, rev, rtype, name := client.SharedFlowResourceFileDecodeId(d.Id())
+	c := m.(*client.Client)
+	//Reading specific file returns actual contents of file so read all files of type and search for name instead
+	retVal, err := c.ListSharedFlowResourceFilesOfType(sharedFlowName, rev, rtype)
+	if err != nil {
+		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	//Look for existence of name
+	found := false
+	for _, file := range retVal {
+		if file.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		d.SetId("")
+		return diags
+	}
+	d.Set("shared_flow_name", sharedFlowName)
+	d.Set("revision", rev)
+	d.Set("type", rtype)
+	d.Set("name", name)
+	return diags
+}
+
+func resourceSharedFlowResourceFileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sharedFlowName, rev, rtype, name := client.SharedFlowResourceFileDecodeId(d.Id())
+	c := m.(*client.Client)
+	upSharedFlowResourceFile := client.ResourceFile{
+		SharedFlowName: sharedFlowName,
+		Revision:       rev,
+		Type:           rtype,
+		Name:           name,
+	}
+	err := c.UpdateSharedFlowResourceFile(&upSharedFlowResourceFile, d.Get("file").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceSharedFlowResourceFileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sharedFlowName, rev, rtype, name := client.SharedFlowResourceFileDecodeId(d.Id())
+	c := m.(*client.Client)
+	err := c.DeleteSharedFlowResourceFile(sharedFlowName, rev, rtype, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
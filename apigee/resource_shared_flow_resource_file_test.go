@@ -0,0 +1,52 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccSharedFlowResourceFile(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_shared_flow_resource_file", testAccSharedFlowResourceFilePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_shared_flow" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+
+resource "apigee_shared_flow_resource_file" "test" {
+  shared_flow_name = apigee_shared_flow.test.name
+  revision         = apigee_shared_flow.test.revision
+  type             = "jsc"
+  name             = "acctest.js"
+  file             = %q
+  file_hash        = "1"
+}
+`, testAccFixture(t, "shared_flow"), testAccFixture(t, "resource.js")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_shared_flow_resource_file.test", testAccSharedFlowResourceFilePath),
+					resource.TestCheckResourceAttr("apigee_shared_flow_resource_file.test", "id", "acctest:1:jsc:acctest.js"),
+				),
+			},
+			{
+				ResourceName:            "apigee_shared_flow_resource_file.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"file", "file_hash"},
+			},
+		},
+	})
+}
+
+func testAccSharedFlowResourceFilePath(rs *terraform.ResourceState) string {
+	sharedFlowName, revision, rtype, name := client.SharedFlowResourceFileDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.SharedFlowResourceFilePathGet, testAccOrganization, sharedFlowName, revision, rtype, name)
+}
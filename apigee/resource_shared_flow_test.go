@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccSharedFlow(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_shared_flow", testAccSharedFlowPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + fmt.Sprintf(`
+resource "apigee_shared_flow" "test" {
+  name       = "acctest"
+  bundle_dir = %q
+}
+`, testAccFixture(t, "shared_flow")),
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_shared_flow.test", testAccSharedFlowPath),
+						resource.TestCheckResourceAttr("apigee_shared_flow.test", "id", "acctest"),
+						resource.TestCheckResourceAttr("apigee_shared_flow.test", "revision", "1"),
+						resource.TestCheckResourceAttrSet("apigee_shared_flow.test", "bundle_hash"),
+					),
+				},
+				{
+					ResourceName:            "apigee_shared_flow.test",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"bundle", "bundle_dir", "bundle_hash", "keep_revisions", "delete_behavior", "skip_bundle_validation"},
+				},
+			},
+		})
+	})
+}
+
+func testAccSharedFlowPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.SharedFlowPathGet, testAccOrganization, rs.Primary.ID)
+}
@@ -1,15 +1,10 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 	"os"
 	"strconv"
 
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -107,7 +102,6 @@ func resourceTargetServer() *schema.Resource {
 func resourceTargetServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	var newTargetServer interface{}
 	if c.IsGoogle() {
 		newTS := client.GoogleTargetServer{
@@ -128,22 +122,12 @@ func resourceTargetServerCreate(ctx context.Context, d *schema.ResourceData, m i
 		fillTargetServer(&newTS, d)
 		newTargetServer = &newTS
 	}
-	err := json.NewEncoder(&buf).Encode(newTargetServer)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	var envName string
+	var err error
 	if c.IsGoogle() {
-		envName = newTargetServer.(*client.GoogleTargetServer).EnvironmentName
+		err = c.CreateGoogleTargetServer(newTargetServer.(*client.GoogleTargetServer))
 	} else {
-		envName = newTargetServer.(*client.TargetServer).EnvironmentName
-	}
-	requestPath := fmt.Sprintf(client.TargetServerPath, c.Organization, envName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
+		err = c.CreateTargetServer(newTargetServer.(*client.TargetServer))
 	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -269,25 +253,18 @@ func resourceTargetServerRead(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	envName, name := client.TargetServerDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.TargetServerPathGet, c.Organization, envName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-	if err != nil {
-		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
-			return diags
-		}
-		return diag.FromErr(err)
-	}
 	var retVal interface{}
+	var err error
 	if c.IsGoogle() {
-		retVal = &client.GoogleTargetServer{}
+		retVal, err = c.GetGoogleTargetServer(envName, name)
 	} else {
-		retVal = &client.TargetServer{}
+		retVal, err = c.GetTargetServer(envName, name)
 	}
-	err = json.NewDecoder(body).Decode(retVal)
 	if err != nil {
 		d.SetId("")
+		if client.IsNotFound(err) {
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 	var host, keyStore, keyAlias, trustStore string
@@ -359,7 +336,6 @@ func resourceTargetServerUpdate(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	envName, name := client.TargetServerDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	var upTargetServer interface{}
 	if c.IsGoogle() {
 		upTS := client.GoogleTargetServer{
@@ -380,15 +356,12 @@ func resourceTargetServerUpdate(ctx context.Context, d *schema.ResourceData, m i
 		fillTargetServer(&upTS, d)
 		upTargetServer = &upTS
 	}
-	err := json.NewEncoder(&buf).Encode(upTargetServer)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.TargetServerPathGet, c.Organization, envName, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
+	var err error
+	if c.IsGoogle() {
+		err = c.UpdateGoogleTargetServer(upTargetServer.(*client.GoogleTargetServer))
+	} else {
+		err = c.UpdateTargetServer(upTargetServer.(*client.TargetServer))
 	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -399,8 +372,7 @@ func resourceTargetServerDelete(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	envName, name := client.TargetServerDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.TargetServerPathGet, c.Organization, envName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteTargetServer(envName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
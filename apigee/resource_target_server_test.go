@@ -0,0 +1,50 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccTargetServer(t *testing.T) {
+	testAccFlavors(t, func(t *testing.T, fake *fakeApigee) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProviderFactories(fake),
+			CheckDestroy:      testAccCheckDestroy(fake, "apigee_target_server", testAccTargetServerPath),
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(fake) + `
+resource "apigee_target_server" "test" {
+  environment_name = "test"
+  name             = "acctest"
+  host             = "backend.example.com"
+  port             = 443
+  ssl_enabled      = true
+  ssl_truststore   = "truststore"
+  protocols        = ["TLSv1.2"]
+}
+`,
+					Check: resource.ComposeTestCheckFunc(
+						testAccCheckExists(fake, "apigee_target_server.test", testAccTargetServerPath),
+						resource.TestCheckResourceAttr("apigee_target_server.test", "id", "test:acctest"),
+						resource.TestCheckResourceAttr("apigee_target_server.test", "is_enabled", "true"),
+						resource.TestCheckResourceAttr("apigee_target_server.test", "ssl_enabled", "true"),
+					),
+				},
+				{
+					ResourceName:      "apigee_target_server.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
+func testAccTargetServerPath(rs *terraform.ResourceState) string {
+	envName, name := client.TargetServerDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.TargetServerPathGet, testAccOrganization, envName, name)
+}
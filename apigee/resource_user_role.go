@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
-	"net/url"
 	"regexp"
 )
 
@@ -45,14 +40,7 @@ func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, m inter
 		EmailId:  d.Get("email_id").(string),
 		RoleName: d.Get("role_name").(string),
 	}
-	requestPath := fmt.Sprintf(client.UserRolePath, c.Organization, newUserRole.RoleName)
-	requestForm := url.Values{
-		"id": []string{newUserRole.EmailId},
-	}
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.FormEncoded},
-	}
-	_, err := c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, bytes.NewBufferString(requestForm.Encode()))
+	err := c.CreateUserRole(&newUserRole)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -65,12 +53,10 @@ func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m interfa
 	var diags diag.Diagnostics
 	emailId, roleName := client.UserRoleDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.UserRolePathGet, c.Organization, roleName, emailId)
-	_, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	_, err := c.GetUserRole(emailId, roleName)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
@@ -85,8 +71,7 @@ func resourceUserRoleDelete(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 	emailId, roleName := client.UserRoleDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.UserRolePathGet, c.Organization, roleName, emailId)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteUserRole(emailId, roleName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
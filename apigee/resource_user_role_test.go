@@ -0,0 +1,53 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccUserRole(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_user_role", testAccUserRolePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_user" "test" {
+  email_id   = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  password   = "Secret123!"
+}
+
+resource "apigee_role" "test" {
+  name = "acctest"
+}
+
+resource "apigee_user_role" "test" {
+  email_id  = apigee_user.test.email_id
+  role_name = apigee_role.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_user_role.test", testAccUserRolePath),
+					resource.TestCheckResourceAttr("apigee_user_role.test", "id", "acctest@example.com:acctest"),
+				),
+			},
+			{
+				ResourceName:      "apigee_user_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserRolePath(rs *terraform.ResourceState) string {
+	emailId, roleName := client.UserRoleDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.UserRolePathGet, testAccOrganization, roleName, emailId)
+}
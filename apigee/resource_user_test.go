@@ -0,0 +1,46 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccUser(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_user", testAccUserPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_user" "test" {
+  email_id   = "acctest@example.com"
+  first_name = "Acc"
+  last_name  = "Test"
+  password   = "Secret123!"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_user.test", testAccUserPath),
+					resource.TestCheckResourceAttr("apigee_user.test", "id", "acctest@example.com"),
+					resource.TestCheckResourceAttr("apigee_user.test", "first_name", "Acc"),
+				),
+			},
+			{
+				ResourceName:      "apigee_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				//The password is never returned
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccUserPath(rs *terraform.ResourceState) string {
+	return fmt.Sprintf(client.UserPathGet, rs.Primary.ID)
+}
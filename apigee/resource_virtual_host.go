@@ -1,16 +1,11 @@
 package apigee
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-apigee/apigee/client"
-	"net/http"
 	"strconv"
 )
 
@@ -83,7 +78,6 @@ func resourceVirtualHost() *schema.Resource {
 func resourceVirtualHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	aliasSet := d.Get("host_aliases").(*schema.Set)
 	aliasList := convertSetToArray(aliasSet)
 	if len(aliasList) == 0 {
@@ -96,16 +90,7 @@ func resourceVirtualHostCreate(ctx context.Context, d *schema.ResourceData, m in
 		HostAliases:     aliasList,
 	}
 	fillVirtualHost(&newVirtualHost, d)
-	err := json.NewEncoder(&buf).Encode(newVirtualHost)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.VirtualHostPath, c.Organization, newVirtualHost.EnvironmentName)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	err := c.CreateVirtualHost(&newVirtualHost)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
@@ -151,22 +136,14 @@ func resourceVirtualHostRead(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	envName, name := client.VirtualHostDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.VirtualHostPathGet, c.Organization, envName, name)
-	body, err := c.HttpRequest(http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	retVal, err := c.GetVirtualHost(envName, name)
 	if err != nil {
 		d.SetId("")
-		re := err.(*client.RequestError)
-		if re.StatusCode == http.StatusNotFound {
+		if client.IsNotFound(err) {
 			return diags
 		}
 		return diag.FromErr(err)
 	}
-	retVal := &client.VirtualHost{}
-	err = json.NewDecoder(body).Decode(retVal)
-	if err != nil {
-		d.SetId("")
-		return diag.FromErr(err)
-	}
 	d.Set("environment_name", envName)
 	d.Set("name", name)
 	d.Set("host_aliases", retVal.HostAliases)
@@ -197,7 +174,6 @@ func resourceVirtualHostUpdate(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	envName, name := client.VirtualHostDecodeId(d.Id())
 	c := m.(*client.Client)
-	buf := bytes.Buffer{}
 	aliasSet := d.Get("host_aliases").(*schema.Set)
 	aliasList := convertSetToArray(aliasSet)
 	if len(aliasList) == 0 {
@@ -209,15 +185,7 @@ func resourceVirtualHostUpdate(ctx context.Context, d *schema.ResourceData, m in
 		HostAliases:     aliasList,
 	}
 	fillVirtualHost(&upVirtualHost, d)
-	err := json.NewEncoder(&buf).Encode(upVirtualHost)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	requestPath := fmt.Sprintf(client.VirtualHostPathGet, c.Organization, envName, name)
-	requestHeaders := http.Header{
-		headers.ContentType: []string{client.ApplicationJson},
-	}
-	_, err = c.HttpRequest(http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	err := c.UpdateVirtualHost(&upVirtualHost)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -228,8 +196,7 @@ func resourceVirtualHostDelete(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	envName, name := client.VirtualHostDecodeId(d.Id())
 	c := m.(*client.Client)
-	requestPath := fmt.Sprintf(client.VirtualHostPathGet, c.Organization, envName, name)
-	_, err := c.HttpRequest(http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	err := c.DeleteVirtualHost(envName, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
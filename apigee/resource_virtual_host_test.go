@@ -0,0 +1,45 @@
+package apigee
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scastria/terraform-provider-apigee/apigee/client"
+	"testing"
+)
+
+func TestAccVirtualHost(t *testing.T) {
+	fake := newFakeApigee(t, false)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(fake),
+		CheckDestroy:      testAccCheckDestroy(fake, "apigee_virtual_host", testAccVirtualHostPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(fake) + `
+resource "apigee_virtual_host" "test" {
+  environment_name = "test"
+  name             = "acctest"
+  host_aliases     = ["api.example.com"]
+  port             = 9001
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(fake, "apigee_virtual_host.test", testAccVirtualHostPath),
+					resource.TestCheckResourceAttr("apigee_virtual_host.test", "id", "test:acctest"),
+					resource.TestCheckResourceAttr("apigee_virtual_host.test", "port", "9001"),
+				),
+			},
+			{
+				ResourceName:      "apigee_virtual_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVirtualHostPath(rs *terraform.ResourceState) string {
+	envName, name := client.VirtualHostDecodeId(rs.Primary.ID)
+	return fmt.Sprintf(client.VirtualHostPathGet, testAccOrganization, envName, name)
+}
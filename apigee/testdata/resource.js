@@ -0,0 +1 @@
+context.setVariable("acceptance.test", "true");
@@ -1,6 +1,12 @@
 package apigee
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strconv"
+	"time"
+)
 
 func convertSetToArray(set *schema.Set) []string {
 	setList := set.List()
@@ -19,3 +25,49 @@ func find(slice []string, val string) (int, bool) {
 	}
 	return -1, false
 }
+
+func findRevision(revisions []int, revision int) (int, bool) {
+	for i, item := range revisions {
+		if item == revision {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func sortRevisions(revisions []string) ([]int, error) {
+	//Apigee returns revisions sorted alphabetically (1, 10, 2, ...) so sort them numerically
+	retVal := []int{}
+	for _, revisionStr := range revisions {
+		rn, err := strconv.Atoi(revisionStr)
+		if err != nil {
+			return nil, err
+		}
+		retVal = append(retVal, rn)
+	}
+	sort.Ints(retVal)
+	return retVal, nil
+}
+
+func formatEpochMillis(millis json.Number) string {
+	//Edge returns timestamps as numbers and Google as strings, both in milliseconds since the epoch
+	ms, err := millis.Int64()
+	if err != nil {
+		return ""
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+func deployedEnvironments(deployedRevisions map[int][]string) []string {
+	//Unique environments across all deployed revisions, sorted for stable messages
+	retVal := []string{}
+	for _, envNames := range deployedRevisions {
+		for _, envName := range envNames {
+			if _, found := find(retVal, envName); !found {
+				retVal = append(retVal, envName)
+			}
+		}
+	}
+	sort.Strings(retVal)
+	return retVal
+}